@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+	"github.com/smallnest/dogclaw/goclaw/internal/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultApprovalTimeout 是等待用户审批的默认超时时间，超时按拒绝处理
+const DefaultApprovalTimeout = 2 * time.Minute
+
+// ApprovalManager 负责发布待审批请求、关联用户响应并缓存"本次会话始终允许"的决定
+type ApprovalManager struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	waiters map[string]chan *bus.ApprovalResponse
+	always  map[string]map[string]bool // sessionKey -> toolName -> always allow
+
+	seq int64
+}
+
+// NewApprovalManager 创建审批管理器，timeout <= 0 时使用 DefaultApprovalTimeout
+func NewApprovalManager(timeout time.Duration) *ApprovalManager {
+	if timeout <= 0 {
+		timeout = DefaultApprovalTimeout
+	}
+	return &ApprovalManager{
+		timeout: timeout,
+		waiters: make(map[string]chan *bus.ApprovalResponse),
+		always:  make(map[string]map[string]bool),
+	}
+}
+
+// IsAlwaysAllowed 返回该会话是否已将该工具标记为"本次会话始终允许"
+func (m *ApprovalManager) IsAlwaysAllowed(sessionKey, toolName string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.always[sessionKey][toolName]
+}
+
+func (m *ApprovalManager) rememberAlwaysAllow(sessionKey, toolName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.always[sessionKey] == nil {
+		m.always[sessionKey] = make(map[string]bool)
+	}
+	m.always[sessionKey][toolName] = true
+}
+
+func (m *ApprovalManager) nextID(sessionKey string) string {
+	m.mu.Lock()
+	m.seq++
+	id := m.seq
+	m.mu.Unlock()
+	return fmt.Sprintf("%s-%d", sessionKey, id)
+}
+
+// RequestApproval 发布一条 PendingApproval 到总线，阻塞等待对应的 ApprovalResponse
+//
+// 超时或 ctx 取消都按拒绝处理。
+func (m *ApprovalManager) RequestApproval(ctx context.Context, b *bus.MessageBus, channel, chatID, sessionKey, toolName string, params map[string]interface{}) bus.ApprovalDecision {
+	id := m.nextID(sessionKey)
+
+	ch := make(chan *bus.ApprovalResponse, 1)
+	m.mu.Lock()
+	m.waiters[id] = ch
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.waiters, id)
+		m.mu.Unlock()
+	}()
+
+	approval := &bus.PendingApproval{
+		ID:          id,
+		SessionKey:  sessionKey,
+		ToolName:    toolName,
+		ToolParams:  params,
+		RequestedAt: time.Now(),
+	}
+
+	if err := b.PublishOutbound(ctx, &bus.OutboundMessage{
+		Channel:   channel,
+		ChatID:    chatID,
+		Timestamp: approval.RequestedAt,
+		Approval:  approval,
+	}); err != nil {
+		logger.Error("Failed to publish pending approval", zap.String("tool", toolName), zap.Error(err))
+		return bus.ApprovalTimedOut
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Decision == bus.ApprovalApproved && resp.AlwaysAllow {
+			m.rememberAlwaysAllow(sessionKey, toolName)
+		}
+		return resp.Decision
+	case <-time.After(m.timeout):
+		logger.Warn("Tool approval timed out, denying by default",
+			zap.String("tool", toolName), zap.String("approval_id", id))
+		return bus.ApprovalTimedOut
+	case <-ctx.Done():
+		return bus.ApprovalTimedOut
+	}
+}
+
+// Resolve 将一条入站 ApprovalResponse 投递给正在等待它的请求
+//
+// 找不到匹配的等待者（例如已超时）时静默丢弃。
+func (m *ApprovalManager) Resolve(resp *bus.ApprovalResponse) {
+	m.mu.Lock()
+	ch, ok := m.waiters[resp.ID]
+	m.mu.Unlock()
+
+	if !ok {
+		logger.Warn("Received approval response with no matching pending approval",
+			zap.String("approval_id", resp.ID))
+		return
+	}
+
+	select {
+	case ch <- resp:
+	default:
+	}
+}
@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+)
+
+func TestApprovalManagerResolveApproves(t *testing.T) {
+	m := NewApprovalManager(2 * time.Second)
+	b := bus.NewMessageBus(4)
+
+	resultCh := make(chan bus.ApprovalDecision, 1)
+	go func() {
+		resultCh <- m.RequestApproval(context.Background(), b, "tg", "chat-1", "tg:chat-1", "run_shell", nil)
+	}()
+
+	// 等待 RequestApproval 把待审批请求发布到出站总线，取出其中生成的 ID
+	out, err := b.ConsumeOutbound(context.Background())
+	if err != nil {
+		t.Fatalf("ConsumeOutbound() error = %v", err)
+	}
+	if out.Approval == nil {
+		t.Fatalf("expected outbound message to carry a PendingApproval")
+	}
+
+	m.Resolve(&bus.ApprovalResponse{ID: out.Approval.ID, Decision: bus.ApprovalApproved})
+
+	select {
+	case decision := <-resultCh:
+		if decision != bus.ApprovalApproved {
+			t.Errorf("decision = %v, want %v", decision, bus.ApprovalApproved)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("RequestApproval did not return after Resolve")
+	}
+}
+
+func TestApprovalManagerResolveAlwaysAllow(t *testing.T) {
+	m := NewApprovalManager(2 * time.Second)
+	b := bus.NewMessageBus(4)
+
+	resultCh := make(chan bus.ApprovalDecision, 1)
+	go func() {
+		resultCh <- m.RequestApproval(context.Background(), b, "tg", "chat-1", "tg:chat-1", "run_shell", nil)
+	}()
+
+	out, err := b.ConsumeOutbound(context.Background())
+	if err != nil {
+		t.Fatalf("ConsumeOutbound() error = %v", err)
+	}
+
+	m.Resolve(&bus.ApprovalResponse{ID: out.Approval.ID, Decision: bus.ApprovalApproved, AlwaysAllow: true})
+	<-resultCh
+
+	if !m.IsAlwaysAllowed("tg:chat-1", "run_shell") {
+		t.Error("expected tool to be remembered as always-allowed for this session")
+	}
+}
+
+func TestApprovalManagerTimesOutWithoutResponse(t *testing.T) {
+	m := NewApprovalManager(50 * time.Millisecond)
+	b := bus.NewMessageBus(4)
+
+	decision := m.RequestApproval(context.Background(), b, "tg", "chat-1", "tg:chat-1", "run_shell", nil)
+	if decision != bus.ApprovalTimedOut {
+		t.Errorf("decision = %v, want %v", decision, bus.ApprovalTimedOut)
+	}
+}
+
+func TestApprovalManagerResolveUnknownIDIsIgnored(t *testing.T) {
+	m := NewApprovalManager(2 * time.Second)
+
+	// 不应 panic 或阻塞
+	m.Resolve(&bus.ApprovalResponse{ID: "no-such-id", Decision: bus.ApprovalApproved})
+}
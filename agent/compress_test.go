@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smallnest/dogclaw/goclaw/providers"
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+func newTestLoopForCompression(provider providers.Provider) *Loop {
+	return &Loop{summarizer: NewSummarizer(provider), summaryPromptTemplate: ""}
+}
+
+func TestCompressSessionLeavesShortSessionUntouched(t *testing.T) {
+	l := newTestLoopForCompression(&fakeProvider{})
+	sess := &session.Session{}
+	for i := 0; i < 3; i++ {
+		sess.Messages = append(sess.Messages, session.Message{Role: "user", Content: "hi"})
+	}
+
+	l.compressSession(context.Background(), sess, nil)
+
+	if len(sess.Messages) != 3 {
+		t.Errorf("expected messages untouched below the retained-turns threshold, got %d", len(sess.Messages))
+	}
+	if sess.Metadata["compression_events"] != nil {
+		t.Errorf("expected no compression event to be recorded, got %v", sess.Metadata["compression_events"])
+	}
+}
+
+func TestCompressSessionSummarizesDroppedMessagesAndKeepsSystemAndRecentTurns(t *testing.T) {
+	provider := &fakeProvider{resp: &providers.ChatResponse{Content: "summary of old turns"}}
+	l := newTestLoopForCompression(provider)
+
+	sess := &session.Session{}
+	sess.Messages = append(sess.Messages, session.Message{Role: "system", Content: "you are a helpful assistant"})
+	// retainedTurns user turns beyond this point must survive untouched.
+	for i := 0; i < retainedTurns+5; i++ {
+		sess.Messages = append(sess.Messages,
+			session.Message{Role: "user", Content: "turn"},
+			session.Message{Role: "assistant", Content: "reply"},
+		)
+	}
+	originalCount := len(sess.Messages)
+
+	l.compressSession(context.Background(), sess, nil)
+
+	if sess.Messages[0].Role != "system" || sess.Messages[0].Content != "you are a helpful assistant" {
+		t.Fatalf("expected the original system message to be preserved first, got %+v", sess.Messages[0])
+	}
+	if sess.Messages[1].Role != "system" || sess.Messages[1].Content == "" {
+		t.Fatalf("expected an inserted system summary message second, got %+v", sess.Messages[1])
+	}
+
+	userTurns := 0
+	for _, m := range sess.Messages[2:] {
+		if m.Role == "user" {
+			userTurns++
+		}
+	}
+	if userTurns != retainedTurns {
+		t.Errorf("expected exactly %d retained user turns, got %d", retainedTurns, userTurns)
+	}
+
+	events, _ := sess.Metadata["compression_events"].([]CompressionEvent)
+	if len(events) != 1 {
+		t.Fatalf("expected one recorded compression event, got %d", len(events))
+	}
+	if events[0].OriginalCount != originalCount || events[0].CompressedCount != len(sess.Messages) {
+		t.Errorf("compression event = %+v, want original=%d compressed=%d", events[0], originalCount, len(sess.Messages))
+	}
+}
+
+func TestCompressSessionFallsBackToTruncationOnSummarizeError(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("provider unavailable")}
+	l := newTestLoopForCompression(provider)
+
+	sess := &session.Session{}
+	for i := 0; i < retainedTurns+5; i++ {
+		sess.Messages = append(sess.Messages, session.Message{Role: "user", Content: "turn"})
+	}
+	originalCount := len(sess.Messages)
+
+	l.compressSession(context.Background(), sess, nil)
+
+	for _, m := range sess.Messages {
+		if m.Role == "system" {
+			t.Fatalf("expected no summary message on fallback, got %+v", sess.Messages)
+		}
+	}
+	if len(sess.Messages) >= originalCount {
+		t.Errorf("expected truncation to drop messages even without a summary, got %d (was %d)", len(sess.Messages), originalCount)
+	}
+
+	events, _ := sess.Metadata["compression_events"].([]CompressionEvent)
+	if len(events) != 1 {
+		t.Fatalf("expected a compression event to be recorded even on the fallback path, got %d", len(events))
+	}
+}
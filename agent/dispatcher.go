@@ -0,0 +1,285 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+	"github.com/smallnest/dogclaw/goclaw/internal/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultDispatchMaxRetries 是单条出站消息发送失败后默认的最大重试次数
+const DefaultDispatchMaxRetries = 3
+
+// DefaultDispatchBaseBackoff 是重试退避的基础间隔，每次重试按 2^n 指数增长
+const DefaultDispatchBaseBackoff = 500 * time.Millisecond
+
+// channelQueueSize 是每个通道 worker 的缓冲队列大小
+const channelQueueSize = 64
+
+// channelDispatchFunc 实际把一条出站消息发送到某个 channel，由 channelDispatcher 的
+// 每个 worker goroutine 调用
+type channelDispatchFunc func(ctx context.Context, msg *bus.OutboundMessage)
+
+// channelDispatcher 按 channel 把出站消息分流到各自独立的 worker goroutine
+//
+// 每个 channel 的 worker 按 FIFO 顺序串行处理自己的队列，保持同一 channel 内的消息顺序
+// （对流式增量尤其重要）；channel 之间完全并发，一个 channel 因限流或重试退避而变慢不会
+// 拖慢其他 channel 的投递。
+type channelDispatcher struct {
+	dispatch channelDispatchFunc
+
+	mu      sync.Mutex
+	workers map[string]chan *bus.OutboundMessage
+}
+
+// newChannelDispatcher 创建一个按 channel 分发的 dispatcher，dispatch 是实际的发送逻辑
+func newChannelDispatcher(dispatch channelDispatchFunc) *channelDispatcher {
+	return &channelDispatcher{dispatch: dispatch, workers: make(map[string]chan *bus.OutboundMessage)}
+}
+
+// enqueue 把一条出站消息投递到其所属 channel 的 worker 队列，首次见到该 channel 时惰性启动 worker
+func (d *channelDispatcher) enqueue(ctx context.Context, msg *bus.OutboundMessage) {
+	queue := d.workerQueue(ctx, msg.Channel)
+	select {
+	case queue <- msg:
+	case <-ctx.Done():
+	}
+}
+
+func (d *channelDispatcher) workerQueue(ctx context.Context, channel string) chan *bus.OutboundMessage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queue, ok := d.workers[channel]
+	if !ok {
+		queue = make(chan *bus.OutboundMessage, channelQueueSize)
+		d.workers[channel] = queue
+		go d.runWorker(ctx, queue)
+	}
+	return queue
+}
+
+func (d *channelDispatcher) runWorker(ctx context.Context, queue chan *bus.OutboundMessage) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-queue:
+			d.dispatch(ctx, msg)
+		}
+	}
+}
+
+// enqueueOutbound 把一条出站消息投递到其所属 channel 的 worker 队列进行异步分发
+func (l *Loop) enqueueOutbound(ctx context.Context, msg *bus.OutboundMessage) {
+	l.channelDispatcher.enqueue(ctx, msg)
+}
+
+// rateLimiter 是一个简单的令牌桶限流器，用于限制单个通道的发送速率
+type rateLimiter struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	burst      float64
+	tokens     float64
+	last       time.Time
+}
+
+func newRateLimiter(ratePerSec float64) *rateLimiter {
+	if ratePerSec <= 0 {
+		return nil
+	}
+	burst := ratePerSec
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{ratePerSec: ratePerSec, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait 阻塞直到获取一个令牌或 ctx 被取消
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.ratePerSec
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		deficit := (1 - l.tokens) / l.ratePerSec
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(deficit * float64(time.Second))):
+		}
+	}
+}
+
+// DeadLetterEntry 记录一条彻底发送失败的出站消息及其最终错误
+type DeadLetterEntry struct {
+	Message  *bus.OutboundMessage
+	Err      error
+	Attempts int
+	Time     time.Time
+}
+
+// DeadLetterQueue 保存彻底发送失败（重试耗尽或通道不存在）的出站消息，供后续排查或人工重发
+type DeadLetterQueue struct {
+	mu      sync.Mutex
+	entries []DeadLetterEntry
+}
+
+// NewDeadLetterQueue 创建一个空的死信队列
+func NewDeadLetterQueue() *DeadLetterQueue {
+	return &DeadLetterQueue{}
+}
+
+// Add 追加一条死信记录
+func (q *DeadLetterQueue) Add(entry DeadLetterEntry) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.entries = append(q.entries, entry)
+}
+
+// Entries 返回当前死信队列中的全部记录快照
+func (q *DeadLetterQueue) Entries() []DeadLetterEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]DeadLetterEntry, len(q.entries))
+	copy(out, q.entries)
+	return out
+}
+
+// streamBuffer 为不支持流式输出的通道缓冲同一个流的增量内容，直到收到 StreamDone 才
+// 一次性 flush 完整文本，而不是每个 delta 都触发一次 Send
+type streamBuffer struct {
+	mu      sync.Mutex
+	pending map[string]*strings.Builder
+}
+
+func newStreamBuffer() *streamBuffer {
+	return &streamBuffer{pending: make(map[string]*strings.Builder)}
+}
+
+// accumulate 把 msg 的内容追加到其所属流的缓冲区；ready 为 false 时表示流尚未结束，
+// 调用方不应发送任何内容，为 true 时返回携带完整累积文本、可直接发送的消息
+func (s *streamBuffer) accumulate(msg *bus.OutboundMessage) (flushed *bus.OutboundMessage, ready bool) {
+	key := msg.Channel + "|" + msg.StreamID
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf, ok := s.pending[key]
+	if !ok {
+		buf = &strings.Builder{}
+		s.pending[key] = buf
+	}
+	buf.WriteString(msg.Content)
+
+	if !msg.StreamDone {
+		return nil, false
+	}
+
+	delete(s.pending, key)
+	out := *msg
+	out.Content = buf.String()
+	return &out, true
+}
+
+// limiterForChannel 返回该通道对应的限流器，没有配置速率限制时返回 nil（不限流）
+func (l *Loop) limiterForChannel(channel string) *rateLimiter {
+	if l.dispatchLimiters == nil {
+		return nil
+	}
+	return l.dispatchLimiters[channel]
+}
+
+// dispatchToChannel 把一条出站消息路由到对应通道的发送器，期间做限流、5xx 指数退避重试，
+// 重试耗尽或通道未注册时写入死信队列
+func (l *Loop) dispatchToChannel(ctx context.Context, msg *bus.OutboundMessage) {
+	sender, ok := l.channels.Get(msg.Channel)
+	if !ok {
+		logger.Warn("No channel sender registered, dropping to dead-letter queue",
+			zap.String("channel", msg.Channel),
+			zap.String("chat_id", msg.ChatID),
+		)
+		l.deadLetters.Add(DeadLetterEntry{Message: msg, Err: fmt.Errorf("no sender registered for channel %q", msg.Channel), Time: time.Now()})
+		return
+	}
+
+	// 不支持流式输出的通道按流缓冲增量内容，只在流结束时发送一次完整文本
+	if msg.StreamID != "" && !sender.SupportsStreaming() {
+		flushed, ready := l.streamBuffers.accumulate(msg)
+		if !ready {
+			return
+		}
+		msg = flushed
+	}
+
+	if err := l.limiterForChannel(msg.Channel).wait(ctx); err != nil {
+		logger.Warn("Dispatch canceled while waiting for rate limit",
+			zap.String("channel", msg.Channel),
+			zap.Error(err),
+		)
+		return
+	}
+
+	send := sender.Send
+	if msg.Approval != nil {
+		if renderer, ok := sender.(bus.ApprovalRenderer); ok {
+			send = renderer.SendApproval
+		}
+	}
+
+	maxRetries := l.dispatchMaxRetries
+	backoff := l.dispatchBaseBackoff
+
+	var lastErr error
+	attempts := 0
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff * time.Duration(1<<uint(attempt-1))):
+			}
+		}
+
+		attempts++
+		if err := send(ctx, msg); err != nil {
+			lastErr = err
+			if !bus.IsRetryable(err) {
+				break
+			}
+			logger.Warn("Channel send failed, will retry",
+				zap.String("channel", msg.Channel),
+				zap.Int("attempt", attempt+1),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		return
+	}
+
+	logger.Error("Channel send permanently failed, moving to dead-letter queue",
+		zap.String("channel", msg.Channel),
+		zap.Int("attempts", attempts),
+		zap.Error(lastErr),
+	)
+	l.deadLetters.Add(DeadLetterEntry{Message: msg, Err: lastErr, Attempts: attempts, Time: time.Now()})
+}
@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+)
+
+func TestChannelDispatcherPreservesPerChannelOrder(t *testing.T) {
+	var mu sync.Mutex
+	var order []string
+
+	d := newChannelDispatcher(func(ctx context.Context, msg *bus.OutboundMessage) {
+		mu.Lock()
+		order = append(order, msg.Content)
+		mu.Unlock()
+	})
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		d.enqueue(ctx, &bus.OutboundMessage{Channel: "telegram", Content: string(rune('a' + i))})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mu.Lock()
+		n := len(order)
+		mu.Unlock()
+		if n == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for all messages to be dispatched, got %d/5", n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"a", "b", "c", "d", "e"}
+	for i, w := range want {
+		if order[i] != w {
+			t.Errorf("order[%d] = %q, want %q (full order: %v)", i, order[i], w, order)
+		}
+	}
+}
+
+// TestChannelDispatcherChannelsDoNotSerialize covers a slow channel's dispatch not
+// blocking delivery to another, concurrently-dispatched channel.
+func TestChannelDispatcherChannelsDoNotSerialize(t *testing.T) {
+	blockSlow := make(chan struct{})
+	fastDone := make(chan struct{}, 1)
+
+	d := newChannelDispatcher(func(ctx context.Context, msg *bus.OutboundMessage) {
+		switch msg.Channel {
+		case "slow":
+			<-blockSlow
+		case "fast":
+			fastDone <- struct{}{}
+		}
+	})
+
+	ctx := context.Background()
+	d.enqueue(ctx, &bus.OutboundMessage{Channel: "slow", Content: "blocks"})
+	d.enqueue(ctx, &bus.OutboundMessage{Channel: "fast", Content: "should not wait on slow"})
+
+	select {
+	case <-fastDone:
+	case <-time.After(time.Second):
+		t.Fatal("fast channel's message was blocked behind the slow channel's worker")
+	}
+
+	close(blockSlow)
+}
+
+func TestStreamBufferFlushesOnlyOnceStreamCompletes(t *testing.T) {
+	buf := newStreamBuffer()
+
+	if _, ready := buf.accumulate(&bus.OutboundMessage{Channel: "wechat", StreamID: "s1", Content: "hel"}); ready {
+		t.Fatal("expected ready=false before StreamDone")
+	}
+	if _, ready := buf.accumulate(&bus.OutboundMessage{Channel: "wechat", StreamID: "s1", Content: "lo "}); ready {
+		t.Fatal("expected ready=false before StreamDone")
+	}
+
+	flushed, ready := buf.accumulate(&bus.OutboundMessage{
+		Channel: "wechat", StreamID: "s1", Content: "world", StreamDone: true,
+	})
+	if !ready {
+		t.Fatal("expected ready=true on StreamDone")
+	}
+	if flushed.Content != "hello world" {
+		t.Errorf("flushed.Content = %q, want %q", flushed.Content, "hello world")
+	}
+}
+
+func TestStreamBufferKeepsStreamsSeparateByChannel(t *testing.T) {
+	buf := newStreamBuffer()
+
+	buf.accumulate(&bus.OutboundMessage{Channel: "wechat", StreamID: "s1", Content: "A"})
+	buf.accumulate(&bus.OutboundMessage{Channel: "telegram", StreamID: "s1", Content: "B"})
+
+	flushed, ready := buf.accumulate(&bus.OutboundMessage{Channel: "wechat", StreamID: "s1", Content: "", StreamDone: true})
+	if !ready || flushed.Content != "A" {
+		t.Errorf("wechat stream flushed = %+v, want Content %q", flushed, "A")
+	}
+
+	flushed, ready = buf.accumulate(&bus.OutboundMessage{Channel: "telegram", StreamID: "s1", Content: "", StreamDone: true})
+	if !ready || flushed.Content != "B" {
+		t.Errorf("telegram stream flushed = %+v, want Content %q", flushed, "B")
+	}
+}
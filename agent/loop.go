@@ -34,6 +34,30 @@ type Loop struct {
 
 	// 反思机制
 	reflector *Reflector
+
+	// 工具调用审批
+	toolPolicy ToolPolicy
+	approvals  *ApprovalManager
+
+	// Agent 画像：按名称/通道选择系统提示词、可用工具与默认技能
+	agents *AgentRegistry
+
+	// 会话压缩与摘要
+	summarizer            *Summarizer
+	summaryPromptTemplate string
+	tokenBudget           int
+
+	// 出站通道分发：按 channel 路由、限流、重试与死信
+	channels            *bus.ChannelRegistry
+	dispatchLimiters    map[string]*rateLimiter
+	dispatchMaxRetries  int
+	dispatchBaseBackoff time.Duration
+	deadLetters         *DeadLetterQueue
+
+	// 每个 channel 独立的 worker 队列，避免一个 channel 的限流/重试拖慢其他 channel
+	channelDispatcher *channelDispatcher
+	// streamBuffers 为不支持流式输出的通道缓冲同一个流的增量内容
+	streamBuffers *streamBuffer
 }
 
 // Config Loop 配置
@@ -50,6 +74,29 @@ type Config struct {
 	MaxIteration  int
 	RetryConfig   *RetryConfig
 	ReflectionCfg *ReflectionConfig
+
+	// ToolPolicyPath 是工具调用审批规则配置文件路径，留空则所有工具默认需要用户确认
+	ToolPolicyPath string
+	// ApprovalTimeout 是等待用户审批的超时时间，<= 0 时使用 DefaultApprovalTimeout
+	ApprovalTimeout time.Duration
+
+	// Agents 是已加载的 Agent 画像注册表，nil 表示不做画像级别的工具/提示词限制
+	Agents *AgentRegistry
+
+	// SummaryPromptTemplate 是默认的会话摘要提示词模板，留空使用 DefaultSummaryPromptTemplate
+	SummaryPromptTemplate string
+	// TokenBudget 是触发主动压缩的会话 token 预算估算值，<= 0 表示不做主动触发（仍可被
+	// /summarize 或上下文溢出错误触发）
+	TokenBudget int
+
+	// Channels 是已注册的出站通道发送器，nil 时所有出站消息都会直接进入死信队列
+	Channels *bus.ChannelRegistry
+	// ChannelRateLimits 配置各 channel 的发送速率上限（条/秒），未配置的通道不限流
+	ChannelRateLimits map[string]float64
+	// DispatchMaxRetries 是出站消息发送失败后的最大重试次数，<= 0 时使用 DefaultDispatchMaxRetries
+	DispatchMaxRetries int
+	// DispatchBaseBackoff 是重试退避的基础间隔，<= 0 时使用 DefaultDispatchBaseBackoff
+	DispatchBaseBackoff time.Duration
 }
 
 // NewLoop 创建 Agent 循环
@@ -65,22 +112,86 @@ func NewLoop(cfg *Config) (*Loop, error) {
 	// 创建反思器
 	reflector := NewReflector(cfg.ReflectionCfg, cfg.Provider, cfg.Workspace)
 
-	return &Loop{
-		bus:             cfg.Bus,
-		provider:        cfg.Provider,
-		sessionMgr:      cfg.SessionMgr,
-		memory:          cfg.Memory,
-		context:         cfg.Context,
-		tools:           cfg.Tools,
-		skillsLoader:    cfg.SkillsLoader,
-		subagents:       cfg.Subagents,
-		workspace:       cfg.Workspace,
-		maxIteration:    cfg.MaxIteration,
-		running:         false,
-		errorClassifier: errorClassifier,
-		retryPolicy:     retryPolicy,
-		reflector:       reflector,
-	}, nil
+	// 加载工具调用审批策略，未配置策略文件时所有工具默认需要用户确认
+	var toolPolicy ToolPolicy
+	if cfg.ToolPolicyPath != "" {
+		loaded, err := LoadToolPolicy(cfg.ToolPolicyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load tool policy: %w", err)
+		}
+		toolPolicy = loaded
+	} else {
+		toolPolicy = NewToolPolicy(nil)
+	}
+
+	dispatchMaxRetries := cfg.DispatchMaxRetries
+	if dispatchMaxRetries <= 0 {
+		dispatchMaxRetries = DefaultDispatchMaxRetries
+	}
+	dispatchBaseBackoff := cfg.DispatchBaseBackoff
+	if dispatchBaseBackoff <= 0 {
+		dispatchBaseBackoff = DefaultDispatchBaseBackoff
+	}
+
+	dispatchLimiters := make(map[string]*rateLimiter, len(cfg.ChannelRateLimits))
+	for channel, rate := range cfg.ChannelRateLimits {
+		dispatchLimiters[channel] = newRateLimiter(rate)
+	}
+
+	loop := &Loop{
+		bus:                   cfg.Bus,
+		provider:              cfg.Provider,
+		sessionMgr:            cfg.SessionMgr,
+		memory:                cfg.Memory,
+		context:               cfg.Context,
+		tools:                 cfg.Tools,
+		skillsLoader:          cfg.SkillsLoader,
+		subagents:             cfg.Subagents,
+		workspace:             cfg.Workspace,
+		maxIteration:          cfg.MaxIteration,
+		running:               false,
+		errorClassifier:       errorClassifier,
+		retryPolicy:           retryPolicy,
+		reflector:             reflector,
+		toolPolicy:            toolPolicy,
+		approvals:             NewApprovalManager(cfg.ApprovalTimeout),
+		agents:                cfg.Agents,
+		summarizer:            NewSummarizer(cfg.Provider),
+		summaryPromptTemplate: cfg.SummaryPromptTemplate,
+		tokenBudget:           cfg.TokenBudget,
+		channels:              cfg.Channels,
+		dispatchLimiters:      dispatchLimiters,
+		dispatchMaxRetries:    dispatchMaxRetries,
+		dispatchBaseBackoff:   dispatchBaseBackoff,
+		deadLetters:           NewDeadLetterQueue(),
+		streamBuffers:         newStreamBuffer(),
+	}
+	loop.channelDispatcher = newChannelDispatcher(loop.dispatchToChannel)
+
+	// 暴露 summarize_conversation 工具，让运行中的 Agent 能在上下文溢出前主动对一段历史消息生成摘要；
+	// 该工具只返回摘要文本，不会删减会话中的历史消息
+	if cfg.Tools != nil {
+		cfg.Tools.Register(tools.NewSummarizeConversationTool(loop.summarizer, cfg.SummaryPromptTemplate))
+	}
+
+	// 子代理按名称委派任务时，复用本循环自身的迭代逻辑来驱动目标画像
+	if loop.subagents != nil {
+		loop.subagents.SetRunner(func(ctx context.Context, sessionKey string, agt *Agent, task string) (string, error) {
+			sess, err := loop.sessionMgr.GetOrCreate(sessionKey)
+			if err != nil {
+				return "", fmt.Errorf("create subagent session: %w", err)
+			}
+			sess.AddMessage(session.Message{Role: "user", Content: task, Timestamp: time.Now()})
+			return loop.runIterationWithRetry(ctx, sess, task, agt)
+		})
+
+		// 暴露 delegate_task 工具，让运行中的 Agent 能主动把子任务委派给其他画像
+		if cfg.Tools != nil {
+			cfg.Tools.Register(tools.NewDelegateTaskTool(loop.subagents))
+		}
+	}
+
+	return loop, nil
 }
 
 // Start 启动 Agent 循环
@@ -130,6 +241,12 @@ func (l *Loop) processMessage(ctx context.Context, msg *bus.InboundMessage) {
 		zap.String("chat_id", msg.ChatID),
 	)
 
+	// 检查是否为用户对待审批工具调用做出的决定
+	if msg.IsApprovalResponse() {
+		l.HandleApprovalResponse(msg.Approval)
+		return
+	}
+
 	// 检查是否为系统消息
 	if msg.IsSystemMessage() {
 		l.processSystemMessage(ctx, msg)
@@ -142,6 +259,23 @@ func (l *Loop) processMessage(ctx context.Context, msg *bus.InboundMessage) {
 		logger.Error("Failed to get session", zap.Error(err))
 		return
 	}
+	sess.Channel = msg.Channel
+	sess.ChatID = msg.ChatID
+
+	// 用户可通过 /summarize 主动触发一次压缩，而不必等到上下文溢出
+	if msg.IsSummarizeCommand() {
+		l.compressSession(ctx, sess, l.agents.Resolve(msg))
+		if err := l.sessionMgr.Save(sess); err != nil {
+			logger.Error("Failed to save session after /summarize", zap.Error(err))
+		}
+		_ = l.bus.PublishOutbound(ctx, &bus.OutboundMessage{
+			Channel:   msg.Channel,
+			ChatID:    msg.ChatID,
+			Content:   "Conversation history has been summarized.",
+			Timestamp: time.Now(),
+		})
+		return
+	}
 
 	// 添加用户消息到会话
 	var media []session.Media
@@ -161,17 +295,27 @@ func (l *Loop) processMessage(ctx context.Context, msg *bus.InboundMessage) {
 		Timestamp: msg.Timestamp,
 	})
 
+	// 根据消息显式指定的 agent 选择器或通道默认配置，解析本次迭代使用的画像
+	agt := l.agents.Resolve(msg)
+
+	// 会话超出配置的 token 预算时，抢在本轮迭代之前主动压缩
+	if l.tokenBudget > 0 && estimateSessionTokens(sess) > l.tokenBudget {
+		logger.Info("Session exceeds token budget, compressing before iteration",
+			zap.Int("token_budget", l.tokenBudget))
+		l.compressSession(ctx, sess, agt)
+	}
+
 	// 运行 Agent 迭代（带重试）
-	response, err := l.runIterationWithRetry(ctx, sess, msg.Content)
+	response, err := l.runIterationWithRetry(ctx, sess, msg.Content, agt)
 	if err != nil {
 		logger.Error("Agent iteration failed", zap.Error(err))
 
 		// 检查是否需要上下文压缩
 		if IsContextOverflowError(err.Error()) {
 			logger.Info("Attempting context compression...")
-			l.compressSession(sess)
+			l.compressSession(ctx, sess, agt)
 			// 重试一次
-			response, err = l.runIterationWithRetry(ctx, sess, msg.Content)
+			response, err = l.runIterationWithRetry(ctx, sess, msg.Content, agt)
 		}
 
 		if err != nil {
@@ -254,8 +398,8 @@ func (l *Loop) processSystemMessage(ctx context.Context, msg *bus.InboundMessage
 	}
 }
 
-// runIterationWithRetry 使用重试机制运行 Agent 迭代
-func (l *Loop) runIterationWithRetry(ctx context.Context, sess *session.Session, userRequest string) (string, error) {
+// runIterationWithRetry 使用重试机制运行 Agent 迭代，agt 为 nil 时不做画像级别的限制
+func (l *Loop) runIterationWithRetry(ctx context.Context, sess *session.Session, userRequest string, agt *Agent) (string, error) {
 	var result string
 	var lastErr error
 
@@ -266,7 +410,7 @@ func (l *Loop) runIterationWithRetry(ctx context.Context, sess *session.Session,
 		attempt++
 		logger.Info("Agent iteration attempt", zap.Int("attempt", attempt))
 
-		result, lastErr = l.runIteration(ctx, sess, userRequest)
+		result, lastErr = l.runIteration(ctx, sess, userRequest, agt)
 		if lastErr == nil {
 			return result, nil
 		}
@@ -301,14 +445,19 @@ func (l *Loop) runIterationWithRetry(ctx context.Context, sess *session.Session,
 	return "", fmt.Errorf("failed after %d attempts: %w", attempt, lastErr)
 }
 
-// runIteration 运行 Agent 迭代（带反思机制）
-func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequest string) (string, error) {
+// runIteration 运行 Agent 迭代（带反思机制），agt 为 nil 时不做画像级别的限制
+func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequest string, agt *Agent) (string, error) {
 	iteration := 0
 	var lastResponse string
 	var continuePrompt string
 
-	// 获取已加载的技能名称（从会话元数据中）
+	// 获取已加载的技能名称（从会话元数据中），并合并画像配置的默认技能
 	loadedSkills := l.getLoadedSkills(sess)
+	for _, s := range agt.defaultSkillsOrNil() {
+		if !containsString(loadedSkills, s) {
+			loadedSkills = append(loadedSkills, s)
+		}
+	}
 
 	for iteration < l.maxIteration {
 		iteration++
@@ -325,6 +474,15 @@ func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequ
 		history := sess.GetHistory(50)
 		messages := l.context.BuildMessages(history, continuePrompt, skills, loadedSkills)
 
+		// 画像配置了系统提示词时，以一条 system 消息置顶，补充默认提示词（默认提示词仍保留在其后）
+		if agt != nil && agt.SystemPrompt != "" {
+			messages = append([]session.Message{{
+				Role:      "system",
+				Content:   agt.SystemPrompt,
+				Timestamp: time.Now(),
+			}}, messages...)
+		}
+
 		providerMessages := make([]providers.Message, len(messages))
 		for i, msg := range messages {
 			var tcs []providers.ToolCall
@@ -350,6 +508,10 @@ func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequ
 			toolList := l.tools.List()
 			logger.Info("Preparing tool definitions", zap.Int("tool_count", len(toolList)))
 			for _, t := range toolList {
+				// 画像限定了工具集合时，过滤掉不在其 glob 白名单内的工具
+				if !agt.AllowsTool(t.Name()) {
+					continue
+				}
 				toolDefs = append(toolDefs, providers.ToolDefinition{
 					Name:        t.Name(),
 					Description: t.Description(),
@@ -359,8 +521,8 @@ func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequ
 			}
 		}
 
-		// 调用 LLM
-		response, err := l.provider.Chat(ctx, providerMessages, toolDefs)
+		// 调用 LLM（流式），逐步组装文本与工具调用参数
+		response, err := l.streamChat(ctx, sess, providerMessages, toolDefs)
 		if err != nil {
 			return "", fmt.Errorf("LLM call failed: %w", err)
 		}
@@ -398,7 +560,7 @@ func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequ
 			// 执行工具调用
 			hasNewSkill := false
 			for _, tc := range response.ToolCalls {
-				result, err := l.executeToolWithRetry(ctx, tc.Name, tc.Params)
+				result, err := l.executeToolWithApproval(ctx, sess, agt, tc.Name, tc.Params)
 				if err != nil {
 					// 工具执行错误不应该终止整个迭代
 					// 将错误信息作为工具结果返回给 LLM
@@ -491,6 +653,103 @@ func (l *Loop) runIteration(ctx context.Context, sess *session.Session, userRequ
 	return lastResponse, nil
 }
 
+// streamChat 通过 provider.ChatStream 发起一次流式调用，将文本增量实时发布到总线，
+// 并把跨多个 chunk 下发的工具调用参数片段组装成完整的工具调用列表后一并返回。
+func (l *Loop) streamChat(ctx context.Context, sess *session.Session, messages []providers.Message, toolDefs []providers.ToolDefinition) (*providers.ChatResponse, error) {
+	streamID := fmt.Sprintf("%s-%d", sess.Key, time.Now().UnixNano())
+
+	deltas, err := l.provider.ChatStream(ctx, messages, toolDefs)
+	if err != nil {
+		return nil, fmt.Errorf("open chat stream: %w", err)
+	}
+
+	var content strings.Builder
+	assembler := newToolCallAssembler()
+
+	for d := range deltas {
+		if d.Err != nil {
+			return nil, fmt.Errorf("chat stream error: %w", d.Err)
+		}
+
+		if d.ContentDelta != "" {
+			content.WriteString(d.ContentDelta)
+			if err := l.bus.PublishOutbound(ctx, &bus.OutboundMessage{
+				Channel:   sess.Channel,
+				ChatID:    sess.ChatID,
+				Content:   d.ContentDelta,
+				Timestamp: time.Now(),
+				StreamID:  streamID,
+			}); err != nil {
+				logger.Warn("Failed to publish stream delta", zap.Error(err))
+			}
+		}
+
+		for _, tc := range d.ToolCallDeltas {
+			assembler.Add(tc)
+		}
+	}
+
+	toolCalls, err := assembler.Finish()
+	if err != nil {
+		return nil, fmt.Errorf("assemble streamed tool calls: %w", err)
+	}
+
+	// 结束标记不再携带文本：所有内容都已作为增量发出，非流式通道的缓冲会把它们拼成完整文本
+	if err := l.bus.PublishOutbound(ctx, &bus.OutboundMessage{
+		Channel:    sess.Channel,
+		ChatID:     sess.ChatID,
+		Timestamp:  time.Now(),
+		StreamID:   streamID,
+		StreamDone: true,
+	}); err != nil {
+		logger.Warn("Failed to publish stream completion", zap.Error(err))
+	}
+
+	return &providers.ChatResponse{Content: content.String(), ToolCalls: toolCalls}, nil
+}
+
+// executeToolWithApproval 在执行工具前先经过审批策略：allow 直接放行，deny 直接拒绝，
+// prompt 则发布 PendingApproval 并阻塞等待用户响应（超时按拒绝处理）。
+// 被拒绝的调用不会当成错误返回，而是生成一条面向 LLM 的合成工具结果，使其可以据此重新规划。
+func (l *Loop) executeToolWithApproval(ctx context.Context, sess *session.Session, agt *Agent, toolName string, params map[string]interface{}) (string, error) {
+	// 注入当前会话（及画像自定义的摘要模板），供 session 相关工具（如 summarize_conversation）读取
+	ctx = tools.WithSession(ctx, sess)
+	if agt != nil && agt.SummaryPromptTemplate != "" {
+		ctx = tools.WithSummaryTemplate(ctx, agt.SummaryPromptTemplate)
+	}
+
+	action := l.toolPolicy.Decide(toolName)
+
+	if action == PolicyPrompt && l.approvals.IsAlwaysAllowed(sess.Key, toolName) {
+		action = PolicyAllow
+	}
+
+	switch action {
+	case PolicyAllow:
+		// 直接放行
+
+	case PolicyPrompt:
+		decision := l.approvals.RequestApproval(ctx, l.bus, sess.Channel, sess.ChatID, sess.Key, toolName, params)
+		if decision != bus.ApprovalApproved {
+			logger.Info("Tool call denied by user",
+				zap.String("tool", toolName), zap.String("decision", string(decision)))
+			return fmt.Sprintf("Tool call to %q was not approved by the user (%s).", toolName, decision), nil
+		}
+
+	default:
+		// PolicyDeny 以及任何未知/非法的裁决值一律按拒绝处理，安全闸门必须 fail closed
+		logger.Info("Tool call denied by policy", zap.String("tool", toolName), zap.String("action", string(action)))
+		return fmt.Sprintf("Tool call to %q was denied by policy and was not executed.", toolName), nil
+	}
+
+	return l.executeToolWithRetry(ctx, toolName, params)
+}
+
+// HandleApprovalResponse 将一条入站的 ApprovalResponse 投递给正在等待它的工具调用
+func (l *Loop) HandleApprovalResponse(resp *bus.ApprovalResponse) {
+	l.approvals.Resolve(resp)
+}
+
 // executeToolWithRetry 使用重试机制执行工具
 func (l *Loop) executeToolWithRetry(ctx context.Context, toolName string, params map[string]interface{}) (string, error) {
 	var result string
@@ -537,41 +796,111 @@ func (l *Loop) executeToolWithRetry(ctx context.Context, toolName string, params
 	return "", fmt.Errorf("tool execution failed: %w", err)
 }
 
-// compressSession 压缩会话历史
-func (l *Loop) compressSession(sess *session.Session) {
+// retainedTurns 是压缩时总是保留在窗口内、不参与摘要的最近对话轮数
+const retainedTurns = 10
+
+// estimateSessionTokens 粗略估算会话当前占用的 token 数（约 4 字符 = 1 token），
+// 仅用于判断是否需要压缩，不要求精确。
+func estimateSessionTokens(sess *session.Session) int {
+	total := 0
+	for _, msg := range sess.Messages {
+		total += len(msg.Content)/4 + 1
+	}
+	return total
+}
+
+// recordCompressionEvent 把本次压缩的概况记录到会话元数据，便于事后排查上下文为何丢失
+func recordCompressionEvent(sess *session.Session, originalCount, compressedCount, droppedCount int) {
+	if sess.Metadata == nil {
+		sess.Metadata = make(map[string]interface{})
+	}
+	events, _ := sess.Metadata["compression_events"].([]CompressionEvent)
+	events = append(events, CompressionEvent{
+		Timestamp:       time.Now(),
+		OriginalCount:   originalCount,
+		CompressedCount: compressedCount,
+		DroppedCount:    droppedCount,
+	})
+	sess.Metadata["compression_events"] = events
+}
+
+// CompressionEvent 记录一次会话压缩的概况
+type CompressionEvent struct {
+	Timestamp       time.Time
+	OriginalCount   int
+	CompressedCount int
+	DroppedCount    int
+}
+
+// compressSession 压缩会话历史：保留系统消息与最近 retainedTurns 轮对话，
+// 其余交给 LLM 生成摘要后作为一条 system 消息插入保留窗口的头部。
+// agt 非空且配置了 SummaryPromptTemplate 时，使用该画像自己的摘要提示词模板。
+func (l *Loop) compressSession(ctx context.Context, sess *session.Session, agt *Agent) {
 	originalCount := len(sess.Messages)
 
-	// 保留最近的 10 轮对话
-	if originalCount > 20 {
-		// 保留系统消息
-		var systemMessages []session.Message
-		var recentMessages []session.Message
-		turnCount := 0
+	var systemMessages, retained, dropped []session.Message
+	turnCount := 0
 
-		for i := len(sess.Messages) - 1; i >= 0; i-- {
-			msg := sess.Messages[i]
+	for i := len(sess.Messages) - 1; i >= 0; i-- {
+		msg := sess.Messages[i]
 
-			if msg.Role == "system" {
-				systemMessages = append([]session.Message{msg}, systemMessages...)
-				continue
-			}
+		if msg.Role == "system" {
+			systemMessages = append([]session.Message{msg}, systemMessages...)
+			continue
+		}
 
+		if turnCount < retainedTurns {
+			retained = append([]session.Message{msg}, retained...)
 			if msg.Role == "user" {
 				turnCount++
-				if turnCount > 10 {
-					break
-				}
 			}
-
-			recentMessages = append([]session.Message{msg}, recentMessages...)
+			continue
 		}
 
-		sess.Messages = append(systemMessages, recentMessages...)
+		dropped = append([]session.Message{msg}, dropped...)
+	}
 
-		logger.Info("Session compressed",
-			zap.Int("original_count", originalCount),
-			zap.Int("compressed_count", len(sess.Messages)))
+	if len(dropped) == 0 {
+		logger.Info("Session below compression threshold, nothing to drop",
+			zap.Int("message_count", originalCount))
+		return
+	}
+
+	template := l.summaryPromptTemplate
+	if agt != nil && agt.SummaryPromptTemplate != "" {
+		template = agt.SummaryPromptTemplate
+	}
+
+	summary, err := l.summarizer.Summarize(ctx, dropped, template)
+	if err != nil {
+		logger.Warn("Failed to summarize dropped messages, falling back to truncation", zap.Error(err))
+		sess.Messages = append(systemMessages, retained...)
+		recordCompressionEvent(sess, originalCount, len(sess.Messages), len(dropped))
+		return
+	}
+
+	summaryMsg := session.Message{
+		Role:      "system",
+		Content:   "Summary of earlier conversation:\n" + summary,
+		Timestamp: time.Now(),
+	}
+	sess.Messages = append(append(systemMessages, summaryMsg), retained...)
+	recordCompressionEvent(sess, originalCount, len(sess.Messages), len(dropped))
+
+	logger.Info("Session compressed via summarization",
+		zap.Int("original_count", originalCount),
+		zap.Int("compressed_count", len(sess.Messages)),
+		zap.Int("dropped_count", len(dropped)))
+}
+
+// containsString 判断字符串切片中是否包含目标值
+func containsString(list []string, target string) bool {
+	for _, v := range list {
+		if v == target {
+			return true
+		}
 	}
+	return false
 }
 
 // getLoadedSkills 从会话中获取已加载的技能名称
@@ -621,8 +950,7 @@ func (l *Loop) dispatchOutbound(ctx context.Context) {
 				zap.String("chat_id", msg.ChatID),
 			)
 
-			// 这里应该根据 channel 调用对应的通道发送器
-			// 暂时只记录日志
+			l.enqueueOutbound(ctx, msg)
 		}
 	}
 }
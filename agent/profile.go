@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+	"gopkg.in/yaml.v3"
+)
+
+// Agent 是一个任务专用的画像：固定的系统提示词、限定的工具集合与默认加载的技能，
+// 用户可以配置多个画像（如 coder、researcher）并在会话中按名称切换，无需重新编译。
+type Agent struct {
+	Name          string   `yaml:"name"`
+	SystemPrompt  string   `yaml:"system_prompt"`
+	ToolGlobs     []string `yaml:"tools"`
+	DefaultSkills []string `yaml:"default_skills"`
+	// Model 记录该画像配置的模型名；Loop 目前不读取该字段，调用 Provider 时不会据此切换模型
+	Model string `yaml:"model"`
+	// SummaryPromptTemplate 非空时覆盖该画像压缩会话时使用的摘要提示词模板
+	SummaryPromptTemplate string `yaml:"summary_prompt_template"`
+}
+
+// AllowsTool 判断该画像是否允许调用指定名称的工具
+//
+// 未配置 ToolGlobs 时视为不限制，允许调用全部工具。
+func (a *Agent) AllowsTool(toolName string) bool {
+	if a == nil || len(a.ToolGlobs) == 0 {
+		return true
+	}
+	for _, g := range a.ToolGlobs {
+		if matched, err := path.Match(g, toolName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSkillsOrNil 返回画像配置的默认技能，a 为 nil 时返回 nil
+func (a *Agent) defaultSkillsOrNil() []string {
+	if a == nil {
+		return nil
+	}
+	return a.DefaultSkills
+}
+
+// AgentRegistry 管理已加载的 Agent 画像及各通道的默认画像
+type AgentRegistry struct {
+	byName        map[string]*Agent
+	defaultByChan map[string]string
+}
+
+// NewAgentRegistry 创建一个空的画像注册表，defaultByChannel 为 channel -> 默认 agent 名称
+func NewAgentRegistry(defaultByChannel map[string]string) *AgentRegistry {
+	return &AgentRegistry{byName: make(map[string]*Agent), defaultByChan: defaultByChannel}
+}
+
+// LoadAgentsDir 从目录下的所有 YAML 文件加载 Agent 画像，通常是 ~/.goclaw/agents/
+func LoadAgentsDir(dir string, defaultByChannel map[string]string) (*AgentRegistry, error) {
+	reg := NewAgentRegistry(defaultByChannel)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read agents dir: %w", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !(strings.HasSuffix(e.Name(), ".yaml") || strings.HasSuffix(e.Name(), ".yml")) {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read agent file %s: %w", e.Name(), err)
+		}
+
+		var agt Agent
+		if err := yaml.Unmarshal(data, &agt); err != nil {
+			return nil, fmt.Errorf("parse agent file %s: %w", e.Name(), err)
+		}
+
+		if agt.Name == "" {
+			agt.Name = strings.TrimSuffix(strings.TrimSuffix(e.Name(), ".yaml"), ".yml")
+		}
+
+		reg.byName[agt.Name] = &agt
+	}
+
+	return reg, nil
+}
+
+// Get 按名称查找已加载的 Agent 画像
+func (r *AgentRegistry) Get(name string) (*Agent, bool) {
+	if r == nil {
+		return nil, false
+	}
+	a, ok := r.byName[name]
+	return a, ok
+}
+
+// Resolve 根据入站消息选择应使用的 Agent 画像：优先取消息显式指定的 agent 选择器，
+// 否则回退到该消息所在通道配置的默认画像；都未命中时返回 nil，表示不做任何限制。
+func (r *AgentRegistry) Resolve(msg *bus.InboundMessage) *Agent {
+	if r == nil {
+		return nil
+	}
+
+	if msg.Agent != "" {
+		if a, ok := r.byName[msg.Agent]; ok {
+			return a
+		}
+	}
+
+	if name, ok := r.defaultByChan[msg.Channel]; ok {
+		if a, ok := r.byName[name]; ok {
+			return a
+		}
+	}
+
+	return nil
+}
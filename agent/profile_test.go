@@ -0,0 +1,73 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+)
+
+func TestAgentAllowsTool(t *testing.T) {
+	tests := []struct {
+		name string
+		agt  *Agent
+		tool string
+		want bool
+	}{
+		{name: "nil agent allows everything", agt: nil, tool: "bash.exec", want: true},
+		{name: "no globs configured allows everything", agt: &Agent{}, tool: "bash.exec", want: true},
+		{name: "matching glob allows", agt: &Agent{ToolGlobs: []string{"fs.*"}}, tool: "fs.read", want: true},
+		{name: "non-matching glob denies", agt: &Agent{ToolGlobs: []string{"fs.*"}}, tool: "bash.exec", want: false},
+		{name: "exact match allows", agt: &Agent{ToolGlobs: []string{"delegate_task"}}, tool: "delegate_task", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.agt.AllowsTool(tt.tool); got != tt.want {
+				t.Errorf("AllowsTool(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentRegistryResolvePrefersExplicitSelector(t *testing.T) {
+	reg := NewAgentRegistry(map[string]string{"telegram": "coder"})
+	coder := &Agent{Name: "coder"}
+	researcher := &Agent{Name: "researcher"}
+	reg.byName["coder"] = coder
+	reg.byName["researcher"] = researcher
+
+	got := reg.Resolve(&bus.InboundMessage{Channel: "telegram", Agent: "researcher"})
+	if got != researcher {
+		t.Errorf("Resolve() = %+v, want the explicitly selected researcher agent", got)
+	}
+}
+
+func TestAgentRegistryResolveFallsBackToChannelDefault(t *testing.T) {
+	reg := NewAgentRegistry(map[string]string{"telegram": "coder"})
+	coder := &Agent{Name: "coder"}
+	reg.byName["coder"] = coder
+
+	got := reg.Resolve(&bus.InboundMessage{Channel: "telegram"})
+	if got != coder {
+		t.Errorf("Resolve() = %+v, want the channel's default coder agent", got)
+	}
+}
+
+func TestAgentRegistryResolveReturnsNilWhenUnresolved(t *testing.T) {
+	reg := NewAgentRegistry(nil)
+
+	if got := reg.Resolve(&bus.InboundMessage{Channel: "telegram"}); got != nil {
+		t.Errorf("Resolve() = %+v, want nil when no selector or channel default matches", got)
+	}
+}
+
+func TestAgentRegistryResolveIgnoresUnknownExplicitSelector(t *testing.T) {
+	reg := NewAgentRegistry(map[string]string{"telegram": "coder"})
+	coder := &Agent{Name: "coder"}
+	reg.byName["coder"] = coder
+
+	got := reg.Resolve(&bus.InboundMessage{Channel: "telegram", Agent: "nonexistent"})
+	if got != coder {
+		t.Errorf("Resolve() = %+v, want fallback to channel default when the explicit selector is unknown", got)
+	}
+}
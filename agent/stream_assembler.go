@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/smallnest/dogclaw/goclaw/providers"
+)
+
+// toolCallAssembler 按 Index 累积流式 Delta 中的工具调用参数片段
+//
+// LLM 供应商把一次工具调用的 JSON 参数拆成多个增量片段下发，ID/Name 只在首个片段出现；
+// 必须在流结束后才能把累积的片段拼成合法 JSON 并解析。
+type toolCallAssembler struct {
+	order []int
+	byIdx map[int]*partialToolCall
+}
+
+type partialToolCall struct {
+	id   string
+	name string
+	args strings.Builder
+}
+
+func newToolCallAssembler() *toolCallAssembler {
+	return &toolCallAssembler{byIdx: make(map[int]*partialToolCall)}
+}
+
+// Add 累积一个工具调用增量片段
+func (a *toolCallAssembler) Add(d providers.ToolCallDelta) {
+	p, ok := a.byIdx[d.Index]
+	if !ok {
+		p = &partialToolCall{}
+		a.byIdx[d.Index] = p
+		a.order = append(a.order, d.Index)
+	}
+	if d.ID != "" {
+		p.id = d.ID
+	}
+	if d.Name != "" {
+		p.name = d.Name
+	}
+	p.args.WriteString(d.ArgsFragment)
+}
+
+// Finish 将累积的片段解析为最终的工具调用列表，按 Index 从小到大排序（与片段到达顺序无关）
+func (a *toolCallAssembler) Finish() ([]providers.ToolCall, error) {
+	sort.Ints(a.order)
+
+	calls := make([]providers.ToolCall, 0, len(a.order))
+	for _, idx := range a.order {
+		p := a.byIdx[idx]
+
+		params := map[string]interface{}{}
+		raw := p.args.String()
+		if strings.TrimSpace(raw) != "" {
+			if err := json.Unmarshal([]byte(raw), &params); err != nil {
+				return nil, fmt.Errorf("assemble tool call %q args: %w", p.name, err)
+			}
+		}
+
+		calls = append(calls, providers.ToolCall{ID: p.id, Name: p.name, Params: params})
+	}
+	return calls, nil
+}
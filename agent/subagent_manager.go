@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+	"github.com/smallnest/dogclaw/goclaw/internal/logger"
+	"go.uber.org/zap"
+)
+
+// SubagentRunner 以指定 Agent 画像运行一次委派任务直到完成，返回最终回复
+//
+// 通常由 Loop 在构造时绑定到自身的迭代逻辑（参见 NewLoop）。
+type SubagentRunner func(ctx context.Context, sessionKey string, agt *Agent, task string) (string, error)
+
+// SubagentManager 负责按名称查找 Agent 画像并把任务委派给其独立运行
+type SubagentManager struct {
+	bus    *bus.MessageBus
+	agents *AgentRegistry
+	runner SubagentRunner
+}
+
+// NewSubagentManager 创建子代理管理器
+func NewSubagentManager(b *bus.MessageBus, agents *AgentRegistry) *SubagentManager {
+	return &SubagentManager{bus: b, agents: agents}
+}
+
+// SetRunner 注入实际执行委派任务的函数
+func (m *SubagentManager) SetRunner(runner SubagentRunner) {
+	m.runner = runner
+}
+
+// SpawnByName 以 agentName 对应的画像异步执行 task，完成后把结果作为系统消息投递回
+// origin 所在的会话，由 Loop.processSystemMessage 转发给用户。
+// 返回的 taskID 可用于在日志或会话元数据中关联这次委派。
+func (m *SubagentManager) SpawnByName(ctx context.Context, origin *bus.InboundMessage, agentName, task string) (string, error) {
+	agt, ok := m.agents.Get(agentName)
+	if !ok {
+		return "", fmt.Errorf("unknown agent: %s", agentName)
+	}
+	if m.runner == nil {
+		return "", fmt.Errorf("subagent manager has no runner configured")
+	}
+
+	taskID := fmt.Sprintf("%s-%d", agentName, time.Now().UnixNano())
+	sessionKey := "subagent:" + taskID
+
+	go func() {
+		result, err := m.runner(ctx, sessionKey, agt, task)
+		if err != nil {
+			logger.Error("Subagent delegation failed",
+				zap.String("agent", agentName), zap.String("task_id", taskID), zap.Error(err))
+			result = fmt.Sprintf("Delegation to agent %q failed: %v", agentName, err)
+		}
+
+		if pubErr := m.bus.PublishInbound(ctx, &bus.InboundMessage{
+			Channel:   origin.Channel,
+			ChatID:    origin.ChatID,
+			Content:   result,
+			Timestamp: time.Now(),
+			Metadata: map[string]interface{}{
+				"task_id":        taskID,
+				"origin_channel": origin.Channel,
+				"origin_chat_id": origin.ChatID,
+			},
+		}); pubErr != nil {
+			logger.Error("Failed to publish subagent completion", zap.Error(pubErr))
+		}
+	}()
+
+	return taskID, nil
+}
@@ -0,0 +1,51 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/smallnest/dogclaw/goclaw/providers"
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+// DefaultSummaryPromptTemplate 是默认的会话摘要提示词模板，%s 会被替换为待压缩的对话文本
+const DefaultSummaryPromptTemplate = "Summarize the following conversation history concisely, preserving any " +
+	"facts, decisions, and open action items the assistant will need later:\n\n%s"
+
+// Summarizer 通过 LLM 对一段对话历史生成摘要
+type Summarizer struct {
+	provider providers.Provider
+}
+
+// NewSummarizer 创建摘要器
+func NewSummarizer(provider providers.Provider) *Summarizer {
+	return &Summarizer{provider: provider}
+}
+
+// Summarize 对 messages 生成摘要，template 为空时使用 DefaultSummaryPromptTemplate
+func (s *Summarizer) Summarize(ctx context.Context, messages []session.Message, template string) (string, error) {
+	if len(messages) == 0 {
+		return "", nil
+	}
+	if template == "" {
+		template = DefaultSummaryPromptTemplate
+	}
+
+	var transcript strings.Builder
+	for _, m := range messages {
+		if m.Content == "" {
+			continue
+		}
+		fmt.Fprintf(&transcript, "%s: %s\n", m.Role, m.Content)
+	}
+
+	prompt := fmt.Sprintf(template, transcript.String())
+
+	resp, err := s.provider.Chat(ctx, []providers.Message{{Role: "user", Content: prompt}}, nil)
+	if err != nil {
+		return "", fmt.Errorf("summarization chat call failed: %w", err)
+	}
+
+	return resp.Content, nil
+}
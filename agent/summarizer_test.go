@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smallnest/dogclaw/goclaw/providers"
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+type fakeProvider struct {
+	lastMessages []providers.Message
+	resp         *providers.ChatResponse
+	err          error
+}
+
+func (f *fakeProvider) Chat(ctx context.Context, messages []providers.Message, tools []providers.ToolDefinition) (*providers.ChatResponse, error) {
+	f.lastMessages = messages
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.resp, nil
+}
+
+func (f *fakeProvider) ChatStream(ctx context.Context, messages []providers.Message, tools []providers.ToolDefinition) (<-chan providers.Delta, error) {
+	panic("not used by these tests")
+}
+
+func TestSummarizerSummarizeReturnsEmptyForNoMessages(t *testing.T) {
+	s := NewSummarizer(&fakeProvider{})
+
+	got, err := s.Summarize(context.Background(), nil, "")
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if got != "" {
+		t.Errorf("Summarize() = %q, want empty string for no messages", got)
+	}
+}
+
+func TestSummarizerSummarizeUsesDefaultTemplateWhenNoneGiven(t *testing.T) {
+	provider := &fakeProvider{resp: &providers.ChatResponse{Content: "a concise summary"}}
+	s := NewSummarizer(provider)
+
+	messages := []session.Message{{Role: "user", Content: "hello"}, {Role: "assistant", Content: "hi there"}}
+	got, err := s.Summarize(context.Background(), messages, "")
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	if got != "a concise summary" {
+		t.Errorf("Summarize() = %q, want the provider's response content", got)
+	}
+
+	if len(provider.lastMessages) != 1 {
+		t.Fatalf("expected exactly one prompt message sent to the provider, got %d", len(provider.lastMessages))
+	}
+	prompt := provider.lastMessages[0].Content
+	if !strings.Contains(prompt, "user: hello") || !strings.Contains(prompt, "assistant: hi there") {
+		t.Errorf("prompt transcript missing expected lines, got:\n%s", prompt)
+	}
+}
+
+func TestSummarizerSummarizeUsesCustomTemplate(t *testing.T) {
+	provider := &fakeProvider{resp: &providers.ChatResponse{Content: "summary"}}
+	s := NewSummarizer(provider)
+
+	messages := []session.Message{{Role: "user", Content: "hello"}}
+	if _, err := s.Summarize(context.Background(), messages, "CUSTOM:\n%s"); err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	prompt := provider.lastMessages[0].Content
+	if !strings.HasPrefix(prompt, "CUSTOM:\n") {
+		t.Errorf("prompt = %q, want it built from the custom template", prompt)
+	}
+}
+
+func TestSummarizerSummarizeSkipsEmptyContentMessages(t *testing.T) {
+	provider := &fakeProvider{resp: &providers.ChatResponse{Content: "summary"}}
+	s := NewSummarizer(provider)
+
+	messages := []session.Message{{Role: "user", Content: ""}, {Role: "user", Content: "real content"}}
+	if _, err := s.Summarize(context.Background(), messages, ""); err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+
+	prompt := provider.lastMessages[0].Content
+	if strings.Count(prompt, "user:") != 1 {
+		t.Errorf("expected only the non-empty message in the transcript, got:\n%s", prompt)
+	}
+}
+
+func TestSummarizerSummarizeWrapsProviderError(t *testing.T) {
+	provider := &fakeProvider{err: errors.New("provider unavailable")}
+	s := NewSummarizer(provider)
+
+	_, err := s.Summarize(context.Background(), []session.Message{{Role: "user", Content: "hi"}}, "")
+	if err == nil {
+		t.Fatal("expected an error when the provider call fails")
+	}
+	if !strings.Contains(err.Error(), "provider unavailable") {
+		t.Errorf("error = %v, want it to wrap the provider's error", err)
+	}
+}
@@ -0,0 +1,115 @@
+package agent
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyAction 是策略引擎对某个工具调用做出的裁决
+type PolicyAction string
+
+const (
+	// PolicyAllow 直接放行，无需用户确认
+	PolicyAllow PolicyAction = "allow"
+	// PolicyDeny 直接拒绝，不会执行
+	PolicyDeny PolicyAction = "deny"
+	// PolicyPrompt 需要用户确认后才能执行
+	PolicyPrompt PolicyAction = "prompt"
+)
+
+// policyRule 是配置文件中的一条规则：工具名 glob -> 裁决
+type policyRule struct {
+	Pattern string
+	Action  PolicyAction
+}
+
+// ToolPolicy 决定某个工具调用是放行、拒绝还是需要用户确认
+type ToolPolicy interface {
+	Decide(toolName string) PolicyAction
+}
+
+// GlobToolPolicy 是基于 glob 规则匹配工具名的 ToolPolicy 实现
+//
+// 规则按配置文件中的先后顺序匹配，命中第一条即返回；都未命中时回退到 PolicyPrompt。
+type GlobToolPolicy struct {
+	rules       []policyRule
+	defaultRule PolicyAction
+}
+
+// NewToolPolicy 基于一组规则构建策略引擎，未命中任何规则时默认 prompt
+func NewToolPolicy(rules []policyRule) *GlobToolPolicy {
+	return &GlobToolPolicy{rules: rules, defaultRule: PolicyPrompt}
+}
+
+// Decide 返回工具名对应的裁决
+func (p *GlobToolPolicy) Decide(toolName string) PolicyAction {
+	for _, r := range p.rules {
+		matched, err := path.Match(r.Pattern, toolName)
+		if err == nil && matched {
+			return r.Action
+		}
+	}
+	return p.defaultRule
+}
+
+// LoadToolPolicy 从 YAML 文件加载工具调用策略配置
+//
+// 文件中规则按声明顺序依次匹配，例如：
+//
+//	bash.*: prompt
+//	fs.read: allow
+//	fs.write: prompt
+func LoadToolPolicy(configPath string) (*GlobToolPolicy, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("read tool policy config: %w", err)
+	}
+
+	// 使用 yaml.Node 保留声明顺序，map 本身是无序的
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse tool policy config: %w", err)
+	}
+
+	rules, err := decodePolicyRules(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("decode tool policy config: %w", err)
+	}
+
+	return NewToolPolicy(rules), nil
+}
+
+func decodePolicyRules(doc *yaml.Node) ([]policyRule, error) {
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("expected a mapping of tool pattern to action")
+	}
+
+	var rules []policyRule
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		pattern := mapping.Content[i].Value
+		action := PolicyAction(mapping.Content[i+1].Value)
+		if !action.valid() {
+			return nil, fmt.Errorf("tool pattern %q: unknown policy action %q (must be one of allow, deny, prompt)", pattern, action)
+		}
+		rules = append(rules, policyRule{Pattern: pattern, Action: action})
+	}
+	return rules, nil
+}
+
+// valid 报告 action 是否为已知的策略裁决之一
+func (a PolicyAction) valid() bool {
+	switch a {
+	case PolicyAllow, PolicyDeny, PolicyPrompt:
+		return true
+	default:
+		return false
+	}
+}
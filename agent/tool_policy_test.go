@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func decodeRulesFromYAML(t *testing.T, raw string) ([]policyRule, error) {
+	t.Helper()
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatalf("yaml.Unmarshal() error = %v", err)
+	}
+	return decodePolicyRules(&doc)
+}
+
+func TestDecodePolicyRulesAcceptsKnownActions(t *testing.T) {
+	rules, err := decodeRulesFromYAML(t, "bash.*: prompt\nfs.read: allow\nfs.write: deny\n")
+	if err != nil {
+		t.Fatalf("decodePolicyRules() error = %v", err)
+	}
+	want := []policyRule{
+		{Pattern: "bash.*", Action: PolicyPrompt},
+		{Pattern: "fs.read", Action: PolicyAllow},
+		{Pattern: "fs.write", Action: PolicyDeny},
+	}
+	if len(rules) != len(want) {
+		t.Fatalf("got %d rules, want %d", len(rules), len(want))
+	}
+	for i, r := range want {
+		if rules[i] != r {
+			t.Errorf("rules[%d] = %+v, want %+v", i, rules[i], r)
+		}
+	}
+}
+
+func TestDecodePolicyRulesRejectsUnknownAction(t *testing.T) {
+	_, err := decodeRulesFromYAML(t, "bash.*: Prompt\n")
+	if err == nil {
+		t.Fatal("expected an error for an unknown policy action, got nil")
+	}
+	if !strings.Contains(err.Error(), "unknown policy action") {
+		t.Errorf("error = %v, want it to mention the unknown action", err)
+	}
+}
+
+func TestGlobToolPolicyDecideFallsBackToPromptWhenNoRuleMatches(t *testing.T) {
+	p := NewToolPolicy([]policyRule{{Pattern: "bash.*", Action: PolicyDeny}})
+	if got := p.Decide("fs.read"); got != PolicyPrompt {
+		t.Errorf("Decide() = %q, want %q", got, PolicyPrompt)
+	}
+}
+
+func TestGlobToolPolicyDecideMatchesFirstRule(t *testing.T) {
+	p := NewToolPolicy([]policyRule{{Pattern: "bash.*", Action: PolicyDeny}})
+	if got := p.Decide("bash.exec"); got != PolicyDeny {
+		t.Errorf("Decide() = %q, want %q", got, PolicyDeny)
+	}
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+)
+
+// Delegator 按名称把一个任务委派给另一个 Agent 画像异步执行；agent.SubagentManager
+// 结构性地实现了该接口
+type Delegator interface {
+	SpawnByName(ctx context.Context, origin *bus.InboundMessage, agentName, task string) (string, error)
+}
+
+// DelegateTaskTool 让当前 Agent 把一个子任务委派给另一个画像处理，用于专家分工场景，
+// 例如让负责对话的画像把一次代码检索任务交给专门的 researcher 画像
+type DelegateTaskTool struct {
+	delegator Delegator
+}
+
+// NewDelegateTaskTool 创建 delegate_task 工具
+func NewDelegateTaskTool(delegator Delegator) *DelegateTaskTool {
+	return &DelegateTaskTool{delegator: delegator}
+}
+
+// Name 返回工具名称
+func (t *DelegateTaskTool) Name() string {
+	return "delegate_task"
+}
+
+// Description 返回工具描述
+func (t *DelegateTaskTool) Description() string {
+	return "Delegate a task to another named agent profile to run independently. The delegate runs " +
+		"asynchronously; its result is delivered back into this conversation once it completes, it is " +
+		"not returned by this call."
+}
+
+// Parameters 返回工具的 JSON Schema 参数定义
+func (t *DelegateTaskTool) Parameters() interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"agent", "task"},
+		"properties": map[string]interface{}{
+			"agent": map[string]interface{}{
+				"type":        "string",
+				"description": "Name of the agent profile to delegate to",
+			},
+			"task": map[string]interface{}{
+				"type":        "string",
+				"description": "The task description to hand off",
+			},
+		},
+	}
+}
+
+// Execute 把任务委派给 params["agent"] 指定的画像，委派发起所属的会话来自 ctx 中注入的
+// 当前会话（见 WithSession）
+func (t *DelegateTaskTool) Execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	sess, ok := SessionFromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("delegate_task: no active session in context")
+	}
+
+	agentName, _ := params["agent"].(string)
+	if agentName == "" {
+		return "", fmt.Errorf("delegate_task: missing required \"agent\" parameter")
+	}
+	task, _ := params["task"].(string)
+	if task == "" {
+		return "", fmt.Errorf("delegate_task: missing required \"task\" parameter")
+	}
+
+	origin := &bus.InboundMessage{Channel: sess.Channel, ChatID: sess.ChatID}
+
+	taskID, err := t.delegator.SpawnByName(ctx, origin, agentName, task)
+	if err != nil {
+		return "", fmt.Errorf("delegate_task: %w", err)
+	}
+
+	return fmt.Sprintf("Delegated to agent %q (task_id=%s); its result will arrive in this conversation once it completes.", agentName, taskID), nil
+}
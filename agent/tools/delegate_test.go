@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smallnest/dogclaw/goclaw/bus"
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+type fakeDelegator struct {
+	gotOrigin *bus.InboundMessage
+	gotAgent  string
+	gotTask   string
+	taskID    string
+	err       error
+}
+
+func (f *fakeDelegator) SpawnByName(ctx context.Context, origin *bus.InboundMessage, agentName, task string) (string, error) {
+	f.gotOrigin = origin
+	f.gotAgent = agentName
+	f.gotTask = task
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.taskID, nil
+}
+
+func TestDelegateTaskToolRequiresSession(t *testing.T) {
+	tool := NewDelegateTaskTool(&fakeDelegator{})
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{"agent": "researcher", "task": "look into X"})
+	if err == nil {
+		t.Fatal("expected error when no session is in context")
+	}
+}
+
+func TestDelegateTaskToolRequiresAgentAndTask(t *testing.T) {
+	tool := NewDelegateTaskTool(&fakeDelegator{})
+	ctx := WithSession(context.Background(), &session.Session{Channel: "telegram", ChatID: "chat-1"})
+
+	if _, err := tool.Execute(ctx, map[string]interface{}{"task": "look into X"}); err == nil {
+		t.Error("expected error when \"agent\" is missing")
+	}
+	if _, err := tool.Execute(ctx, map[string]interface{}{"agent": "researcher"}); err == nil {
+		t.Error("expected error when \"task\" is missing")
+	}
+}
+
+func TestDelegateTaskToolSpawnsWithSessionOrigin(t *testing.T) {
+	fake := &fakeDelegator{taskID: "researcher-123"}
+	tool := NewDelegateTaskTool(fake)
+	ctx := WithSession(context.Background(), &session.Session{Channel: "telegram", ChatID: "chat-1"})
+
+	result, err := tool.Execute(ctx, map[string]interface{}{"agent": "researcher", "task": "look into X"})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if fake.gotAgent != "researcher" || fake.gotTask != "look into X" {
+		t.Errorf("delegator got agent=%q task=%q", fake.gotAgent, fake.gotTask)
+	}
+	if fake.gotOrigin == nil || fake.gotOrigin.Channel != "telegram" || fake.gotOrigin.ChatID != "chat-1" {
+		t.Errorf("delegator got origin = %+v, want Channel=telegram ChatID=chat-1", fake.gotOrigin)
+	}
+	if !contains(result, "researcher-123") {
+		t.Errorf("result = %q, want it to mention the task ID", result)
+	}
+}
+
+func TestDelegateTaskToolPropagatesDelegatorError(t *testing.T) {
+	fake := &fakeDelegator{err: errors.New("unknown agent")}
+	tool := NewDelegateTaskTool(fake)
+	ctx := WithSession(context.Background(), &session.Session{Channel: "telegram", ChatID: "chat-1"})
+
+	_, err := tool.Execute(ctx, map[string]interface{}{"agent": "nope", "task": "x"})
+	if err == nil {
+		t.Fatal("expected error to propagate from delegator")
+	}
+}
+
+func contains(s, substr string) bool {
+	return len(s) >= len(substr) && (func() bool {
+		for i := 0; i+len(substr) <= len(s); i++ {
+			if s[i:i+len(substr)] == substr {
+				return true
+			}
+		}
+		return false
+	})()
+}
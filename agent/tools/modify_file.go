@@ -0,0 +1,342 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// diffContext 是生成统一 diff 时每个 hunk 前后保留的上下文行数
+const diffContext = 3
+
+// FileOperation 是 modify_file 工具的一次编辑操作
+type FileOperation struct {
+	// Type 取值为 "replace"、"insert" 或 "delete"
+	Type string `json:"type"`
+	// StartLine/EndLine 是 1 基的行号范围（含两端）。insert 操作要求 EndLine == StartLine-1，
+	// 表示在 StartLine 之前插入，不消费任何原有行。
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+	Content   string `json:"content"`
+}
+
+// ModifyFileTool 以一批行级操作原子地修改一个文件
+//
+// 所有操作先按 start_line 从大到小排序后依次应用，避免前面的编辑改变后面操作引用的行号。
+// 任何一条操作未通过校验都不会写入磁盘；写入本身通过临时文件 + rename 保证原子性。
+type ModifyFileTool struct{}
+
+// NewModifyFileTool 创建 modify_file 工具
+func NewModifyFileTool() *ModifyFileTool {
+	return &ModifyFileTool{}
+}
+
+// Name 返回工具名称
+func (t *ModifyFileTool) Name() string {
+	return "modify_file"
+}
+
+// Description 返回工具描述
+func (t *ModifyFileTool) Description() string {
+	return "Apply a batch of replace/insert/delete line operations to a file in a single atomic write, " +
+		"returning a unified diff of the change."
+}
+
+// Parameters 返回工具的 JSON Schema 参数定义
+func (t *ModifyFileTool) Parameters() interface{} {
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []string{"path", "operations"},
+		"properties": map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":        "string",
+				"description": "Path of the file to modify",
+			},
+			"operations": map[string]interface{}{
+				"type": "array",
+				"items": map[string]interface{}{
+					"type":     "object",
+					"required": []string{"type", "start_line", "end_line"},
+					"properties": map[string]interface{}{
+						"type":       map[string]interface{}{"type": "string", "enum": []string{"replace", "insert", "delete"}},
+						"start_line": map[string]interface{}{"type": "integer"},
+						"end_line":   map[string]interface{}{"type": "integer"},
+						"content":    map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Execute 校验并应用一批操作，返回应用后的统一 diff 作为工具结果
+func (t *ModifyFileTool) Execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	path, ok := params["path"].(string)
+	if !ok || path == "" {
+		return "", fmt.Errorf("modify_file: missing required \"path\" parameter")
+	}
+
+	ops, err := parseFileOperations(params["operations"])
+	if err != nil {
+		return "", fmt.Errorf("modify_file: %w", err)
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("modify_file: read %s: %w", path, err)
+	}
+	originalLines := splitFileLines(string(original))
+
+	if err := validateFileOperations(ops, len(originalLines)); err != nil {
+		return "", fmt.Errorf("modify_file: %w", err)
+	}
+
+	updatedLines := applyFileOperations(originalLines, ops)
+	diff := buildUnifiedDiff(path, originalLines, ops)
+
+	if err := writeFileAtomic(path, joinFileLines(updatedLines)); err != nil {
+		return "", fmt.Errorf("modify_file: %w", err)
+	}
+
+	return diff, nil
+}
+
+func parseFileOperations(raw interface{}) ([]FileOperation, error) {
+	if raw == nil {
+		return nil, fmt.Errorf("missing required \"operations\" parameter")
+	}
+
+	// params 来自通用的 map[string]interface{}，借道 JSON 重新编解码成强类型切片
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("encode operations: %w", err)
+	}
+
+	var ops []FileOperation
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, fmt.Errorf("decode operations: %w", err)
+	}
+	if len(ops) == 0 {
+		return nil, fmt.Errorf("operations must not be empty")
+	}
+
+	return ops, nil
+}
+
+// validateFileOperations 校验每条操作的行号范围合法且互不重叠
+func validateFileOperations(ops []FileOperation, lineCount int) error {
+	type span struct {
+		start, end int
+	}
+	spans := make([]span, 0, len(ops))
+
+	for _, op := range ops {
+		switch op.Type {
+		case "replace", "delete":
+			if op.EndLine < op.StartLine {
+				return fmt.Errorf("operation on lines %d-%d: end_line must be >= start_line", op.StartLine, op.EndLine)
+			}
+			if op.StartLine < 1 || op.EndLine > lineCount {
+				return fmt.Errorf("operation on lines %d-%d: range does not exist in a %d-line file", op.StartLine, op.EndLine, lineCount)
+			}
+			spans = append(spans, span{op.StartLine, op.EndLine})
+
+		case "insert":
+			if op.EndLine != op.StartLine-1 {
+				return fmt.Errorf("insert at line %d: end_line must equal start_line-1, got %d", op.StartLine, op.EndLine)
+			}
+			if op.StartLine < 1 || op.StartLine > lineCount+1 {
+				return fmt.Errorf("insert at line %d: position does not exist in a %d-line file", op.StartLine, lineCount)
+			}
+			spans = append(spans, span{op.StartLine, op.StartLine - 1})
+
+		default:
+			return fmt.Errorf("unknown operation type %q", op.Type)
+		}
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].start <= spans[i-1].end {
+			return fmt.Errorf("operations overlap: lines %d-%d and %d-%d", spans[i-1].start, spans[i-1].end, spans[i].start, spans[i].end)
+		}
+	}
+
+	return nil
+}
+
+// applyFileOperations 将操作按 start_line 从大到小排序后依次应用，保证行号不会中途偏移
+func applyFileOperations(original []string, ops []FileOperation) []string {
+	sorted := append([]FileOperation(nil), ops...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartLine > sorted[j].StartLine })
+
+	lines := append([]string(nil), original...)
+	for _, op := range sorted {
+		startIdx := op.StartLine - 1
+		endIdx := op.EndLine - 1
+
+		var insert []string
+		if op.Type != "delete" {
+			insert = splitFileLines(op.Content)
+		}
+
+		lines = spliceLines(lines, startIdx, endIdx, insert)
+	}
+
+	return lines
+}
+
+// spliceLines 用 insert 替换 lines[start:end+1]（end < start 时视为在 start 处的空区间，即纯插入）
+func spliceLines(lines []string, start, end int, insert []string) []string {
+	removed := end - start + 1
+	if removed < 0 {
+		removed = 0
+	}
+
+	result := make([]string, 0, len(lines)-removed+len(insert))
+	result = append(result, lines[:start]...)
+	result = append(result, insert...)
+	if end+1 <= len(lines) {
+		result = append(result, lines[end+1:]...)
+	}
+	return result
+}
+
+func splitFileLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func joinFileLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// diffSpan 是一条操作在编辑前文本中触达的行号范围及其上下文窗口
+//
+// oldEnd 可能等于 oldStart-1（insert 操作），表示不消费任何原有行。
+type diffSpan struct {
+	oldStart, oldEnd int
+	added            []string
+	winStart, winEnd int
+}
+
+// buildUnifiedDiff 生成一份基于编辑前行号、但正确反映编辑后行号偏移的 unified diff
+//
+// 各操作按 start_line 升序处理；上下文窗口相互重叠或相邻的操作会被合并进同一个 hunk，
+// 避免出现同一段原始行在一个 hunk 里是未变更上下文、在另一个 hunk 里又已被替换的非法 diff。
+// 每个 hunk 的新行号通过累加其之前所有 hunk 的"新增行数 - 删除行数"得到，而不是照搬旧行号。
+func buildUnifiedDiff(path string, original []string, ops []FileOperation) string {
+	sorted := append([]FileOperation(nil), ops...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartLine < sorted[j].StartLine })
+
+	spans := make([]diffSpan, len(sorted))
+	for i, op := range sorted {
+		oldStart, oldEnd := op.StartLine, op.EndLine
+		if op.Type == "insert" {
+			oldEnd = op.StartLine - 1
+		}
+		var added []string
+		if op.Type != "delete" {
+			// delete 操作不插入任何行，即便调用方误传了 content 也不应体现在 diff 里，
+			// 否则展示的变更会和 applyFileOperations 实际写入磁盘的内容不一致
+			added = splitFileLines(op.Content)
+		}
+		spans[i] = diffSpan{
+			oldStart: oldStart,
+			oldEnd:   oldEnd,
+			added:    added,
+			winStart: max(1, oldStart-diffContext),
+			winEnd:   min(len(original), oldEnd+diffContext),
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	offset := 0 // 之前已完整处理的 hunk 带来的"新行数 - 旧行数"累计偏移
+	for i := 0; i < len(spans); {
+		j := i
+		clusterEnd := spans[i].winEnd
+		for j+1 < len(spans) && spans[j+1].winStart <= clusterEnd+1 {
+			j++
+			clusterEnd = max(clusterEnd, spans[j].winEnd)
+		}
+		cluster := spans[i : j+1]
+		clusterStart := cluster[0].winStart
+		newStart := clusterStart + offset
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		clusterOffset := 0
+		cursor := clusterStart
+		for _, s := range cluster {
+			for ; cursor < s.oldStart; cursor++ {
+				fmt.Fprintf(&body, " %s\n", original[cursor-1])
+				oldCount++
+				newCount++
+			}
+			for k := s.oldStart; k <= s.oldEnd; k++ {
+				fmt.Fprintf(&body, "-%s\n", original[k-1])
+				oldCount++
+			}
+			for _, l := range s.added {
+				fmt.Fprintf(&body, "+%s\n", l)
+				newCount++
+			}
+			clusterOffset += len(s.added) - (s.oldEnd - s.oldStart + 1)
+			cursor = s.oldEnd + 1
+		}
+		for ; cursor <= clusterEnd; cursor++ {
+			fmt.Fprintf(&body, " %s\n", original[cursor-1])
+			oldCount++
+			newCount++
+		}
+
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", clusterStart, oldCount, newStart, newCount)
+		b.WriteString(body.String())
+
+		offset += clusterOffset
+		i = j + 1
+	}
+
+	return b.String()
+}
+
+// writeFileAtomic 写入临时文件后 rename 到目标路径，确保不会留下部分写入的文件
+func writeFileAtomic(path, content string) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, ".modify_file-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.WriteString(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temp file: %w", err)
+	}
+
+	if info, statErr := os.Stat(path); statErr == nil {
+		_ = os.Chmod(tmpPath, info.Mode())
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp file into place: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildUnifiedDiffSingleReplace(t *testing.T) {
+	original := []string{"a", "b", "c", "d", "e"}
+	ops := []FileOperation{{Type: "replace", StartLine: 3, EndLine: 3, Content: "C"}}
+
+	diff := buildUnifiedDiff("f.txt", original, ops)
+
+	if !strings.Contains(diff, "@@ -1,5 +1,5 @@") {
+		t.Errorf("unexpected hunk header, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "-c\n") || !strings.Contains(diff, "+C\n") {
+		t.Errorf("expected -c/+C lines, got:\n%s", diff)
+	}
+}
+
+// TestBuildUnifiedDiffOverlappingContextMerges covers two operations whose context
+// windows overlap once an earlier op has already changed the line count: both touch
+// lines close enough together (within 2*diffContext) that they must be merged into a
+// single hunk with correct, offset-adjusted new-side line numbers rather than two
+// hunks that show the same original lines as unmodified context in one and already
+// replaced in the other.
+func TestBuildUnifiedDiffOverlappingContextMerges(t *testing.T) {
+	original := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	ops := []FileOperation{
+		{Type: "replace", StartLine: 2, EndLine: 2, Content: "B1\nB2"},
+		{Type: "replace", StartLine: 5, EndLine: 5, Content: "E"},
+	}
+
+	diff := buildUnifiedDiff("f.txt", original, ops)
+	lines := strings.Split(diff, "\n")
+
+	var hunkHeaders []string
+	for _, l := range lines {
+		if strings.HasPrefix(l, "@@") {
+			hunkHeaders = append(hunkHeaders, l)
+		}
+	}
+
+	if len(hunkHeaders) != 1 {
+		t.Fatalf("expected the two nearby ops to merge into a single hunk, got %d hunks:\n%s", len(hunkHeaders), diff)
+	}
+
+	want := "@@ -1,8 +1,9 @@"
+	if hunkHeaders[0] != want {
+		t.Errorf("hunk header = %q, want %q\nfull diff:\n%s", hunkHeaders[0], want, diff)
+	}
+
+	if !strings.Contains(diff, "-b\n") || !strings.Contains(diff, "+B1\n") || !strings.Contains(diff, "+B2\n") {
+		t.Errorf("expected first op's removed/added lines, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "-e\n") || !strings.Contains(diff, "+E\n") {
+		t.Errorf("expected second op's removed/added lines, got:\n%s", diff)
+	}
+}
+
+// TestBuildUnifiedDiffSecondHunkOffset covers two operations far enough apart that they
+// produce separate hunks, asserting the second hunk's new-side start line accounts for
+// the line-count change introduced by the first.
+func TestBuildUnifiedDiffSecondHunkOffset(t *testing.T) {
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = strings.Repeat("l", i+1)
+	}
+	ops := []FileOperation{
+		{Type: "replace", StartLine: 2, EndLine: 2, Content: "x\ny\nz"}, // +2 net lines
+		{Type: "replace", StartLine: 30, EndLine: 30, Content: "w"},
+	}
+
+	diff := buildUnifiedDiff("f.txt", lines, ops)
+	hunkHeaders := []string{}
+	for _, l := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(l, "@@") {
+			hunkHeaders = append(hunkHeaders, l)
+		}
+	}
+
+	if len(hunkHeaders) != 2 {
+		t.Fatalf("expected 2 separate hunks, got %d:\n%s", len(hunkHeaders), diff)
+	}
+
+	// Second hunk's old side starts at 30-diffContext=27; new side must be shifted
+	// by the +2 lines the first hunk added.
+	if !strings.Contains(hunkHeaders[1], "+29,") {
+		t.Errorf("second hunk header did not account for first hunk's line-count delta: %q", hunkHeaders[1])
+	}
+}
+
+// TestBuildUnifiedDiffDeleteIgnoresStrayContent covers a delete operation that carries
+// a non-empty content field (an easy LLM mistake). applyFileOperations never consumes
+// content for delete ops, so the diff must not show it as added either, or the diff
+// would misrepresent what was actually written to disk.
+func TestBuildUnifiedDiffDeleteIgnoresStrayContent(t *testing.T) {
+	original := []string{"a", "b", "c", "d", "e"}
+	ops := []FileOperation{{Type: "delete", StartLine: 3, EndLine: 3, Content: "ZZZ"}}
+
+	diff := buildUnifiedDiff("f.txt", original, ops)
+
+	if strings.Contains(diff, "ZZZ") {
+		t.Errorf("delete op's stray content must not appear in the diff, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "@@ -1,5 +1,4 @@") {
+		t.Errorf("unexpected hunk header for a delete op, got:\n%s", diff)
+	}
+	if !strings.Contains(diff, "-c\n") {
+		t.Errorf("expected the deleted line to appear as removed, got:\n%s", diff)
+	}
+}
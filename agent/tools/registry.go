@@ -0,0 +1,59 @@
+// Package tools 提供工具定义与注册表，供 agent.Loop 在迭代中调用。
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Tool 是所有可被 LLM 调用的工具必须实现的接口
+type Tool interface {
+	Name() string
+	Description() string
+	Parameters() interface{}
+	Execute(ctx context.Context, params map[string]interface{}) (string, error)
+}
+
+// Registry 管理已注册的工具集合
+type Registry struct {
+	mu    sync.RWMutex
+	tools map[string]Tool
+}
+
+// NewRegistry 创建一个空的工具注册表
+func NewRegistry() *Registry {
+	return &Registry{tools: make(map[string]Tool)}
+}
+
+// Register 注册一个工具，同名工具会被覆盖
+func (r *Registry) Register(t Tool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools[t.Name()] = t
+}
+
+// List 返回当前已注册的全部工具
+func (r *Registry) List() []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list := make([]Tool, 0, len(r.tools))
+	for _, t := range r.tools {
+		list = append(list, t)
+	}
+	return list
+}
+
+// Execute 按名称执行工具
+func (r *Registry) Execute(ctx context.Context, name string, params map[string]interface{}) (string, error) {
+	r.mu.RLock()
+	t, ok := r.tools[name]
+	r.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("unknown tool: %s", name)
+	}
+
+	return t.Execute(ctx, params)
+}
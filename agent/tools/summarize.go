@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+type sessionCtxKey struct{}
+type summaryTemplateCtxKey struct{}
+
+// WithSession 把当前活跃会话注入 ctx，供 Execute 内部的会话相关工具（如 summarize_conversation）读取，
+// 避免为此把会话引用塞进每个工具调用的 params。
+func WithSession(ctx context.Context, sess *session.Session) context.Context {
+	return context.WithValue(ctx, sessionCtxKey{}, sess)
+}
+
+// SessionFromContext 取出由 WithSession 注入的当前会话
+func SessionFromContext(ctx context.Context) (*session.Session, bool) {
+	sess, ok := ctx.Value(sessionCtxKey{}).(*session.Session)
+	return sess, ok
+}
+
+// WithSummaryTemplate 把当前 Agent 画像配置的摘要提示词模板注入 ctx
+func WithSummaryTemplate(ctx context.Context, template string) context.Context {
+	return context.WithValue(ctx, summaryTemplateCtxKey{}, template)
+}
+
+func summaryTemplateFromContext(ctx context.Context) string {
+	template, _ := ctx.Value(summaryTemplateCtxKey{}).(string)
+	return template
+}
+
+// Summarizer 生成一段对话历史的摘要；agent.Summarizer 结构性地实现了该接口
+type Summarizer interface {
+	Summarize(ctx context.Context, messages []session.Message, template string) (string, error)
+}
+
+// SummarizeConversationTool 让 LLM 主动对一段历史消息做摘要，摘要作为工具结果返回；
+// 会话中的历史消息本身不会被删减或替换
+type SummarizeConversationTool struct {
+	summarizer      Summarizer
+	defaultTemplate string
+}
+
+// NewSummarizeConversationTool 创建 summarize_conversation 工具，defaultTemplate 为空时
+// 使用调用方 Summarizer 实现自身的默认模板
+func NewSummarizeConversationTool(summarizer Summarizer, defaultTemplate string) *SummarizeConversationTool {
+	return &SummarizeConversationTool{summarizer: summarizer, defaultTemplate: defaultTemplate}
+}
+
+// Name 返回工具名称
+func (t *SummarizeConversationTool) Name() string {
+	return "summarize_conversation"
+}
+
+// Description 返回工具描述
+func (t *SummarizeConversationTool) Description() string {
+	return "Summarize a range of the current conversation history, useful for proactively compressing " +
+		"context before it overflows."
+}
+
+// Parameters 返回工具的 JSON Schema 参数定义
+func (t *SummarizeConversationTool) Parameters() interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"start": map[string]interface{}{
+				"type":        "integer",
+				"description": "0-based index of the first message to summarize (inclusive); defaults to 0",
+			},
+			"end": map[string]interface{}{
+				"type":        "integer",
+				"description": "0-based index of the last message to summarize (exclusive); defaults to the end of history",
+			},
+		},
+	}
+}
+
+// Execute 对当前会话中 [start, end) 范围内的消息生成摘要
+func (t *SummarizeConversationTool) Execute(ctx context.Context, params map[string]interface{}) (string, error) {
+	sess, ok := SessionFromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("summarize_conversation: no active session in context")
+	}
+
+	history := sess.GetHistory(0)
+	start, end := summarizeRange(params, len(history))
+	if start < 0 || end > len(history) || start >= end {
+		return "", fmt.Errorf("summarize_conversation: invalid range [%d,%d) for %d messages", start, end, len(history))
+	}
+
+	template := t.defaultTemplate
+	if override := summaryTemplateFromContext(ctx); override != "" {
+		template = override
+	}
+
+	summary, err := t.summarizer.Summarize(ctx, history[start:end], template)
+	if err != nil {
+		return "", fmt.Errorf("summarize_conversation: %w", err)
+	}
+
+	return summary, nil
+}
+
+func summarizeRange(params map[string]interface{}, total int) (int, int) {
+	start, end := 0, total
+	if v, ok := params["start"].(float64); ok {
+		start = int(v)
+	}
+	if v, ok := params["end"].(float64); ok {
+		end = int(v)
+	}
+	return start, end
+}
@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/smallnest/dogclaw/goclaw/session"
+)
+
+type fakeSummarizer struct {
+	gotMessages []session.Message
+	gotTemplate string
+	result      string
+	err         error
+}
+
+func (f *fakeSummarizer) Summarize(ctx context.Context, messages []session.Message, template string) (string, error) {
+	f.gotMessages = messages
+	f.gotTemplate = template
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.result, nil
+}
+
+func TestSummarizeConversationToolRequiresSession(t *testing.T) {
+	tool := NewSummarizeConversationTool(&fakeSummarizer{}, "")
+
+	_, err := tool.Execute(context.Background(), map[string]interface{}{})
+	if err == nil {
+		t.Fatal("expected error when no session is in context")
+	}
+}
+
+func TestSummarizeConversationToolDefaultsToFullHistory(t *testing.T) {
+	fake := &fakeSummarizer{result: "summary"}
+	tool := NewSummarizeConversationTool(fake, "default template %s")
+
+	sess := &session.Session{}
+	sess.AddMessage(session.Message{Role: "user", Content: "one"})
+	sess.AddMessage(session.Message{Role: "assistant", Content: "two"})
+	ctx := WithSession(context.Background(), sess)
+
+	result, err := tool.Execute(ctx, map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if result != "summary" {
+		t.Errorf("result = %q, want the summarizer's output", result)
+	}
+	if len(fake.gotMessages) != 2 {
+		t.Errorf("expected the full history (2 messages) to be summarized, got %d", len(fake.gotMessages))
+	}
+	if fake.gotTemplate != "default template %s" {
+		t.Errorf("gotTemplate = %q, want the tool's default template", fake.gotTemplate)
+	}
+}
+
+func TestSummarizeConversationToolHonorsStartEndRange(t *testing.T) {
+	fake := &fakeSummarizer{result: "summary"}
+	tool := NewSummarizeConversationTool(fake, "")
+
+	sess := &session.Session{}
+	for i := 0; i < 5; i++ {
+		sess.AddMessage(session.Message{Role: "user", Content: "msg"})
+	}
+	ctx := WithSession(context.Background(), sess)
+
+	_, err := tool.Execute(ctx, map[string]interface{}{"start": float64(1), "end": float64(3)})
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(fake.gotMessages) != 2 {
+		t.Errorf("expected [1,3) to select 2 messages, got %d", len(fake.gotMessages))
+	}
+}
+
+func TestSummarizeConversationToolRejectsInvalidRange(t *testing.T) {
+	tool := NewSummarizeConversationTool(&fakeSummarizer{}, "")
+
+	sess := &session.Session{}
+	sess.AddMessage(session.Message{Role: "user", Content: "msg"})
+	ctx := WithSession(context.Background(), sess)
+
+	_, err := tool.Execute(ctx, map[string]interface{}{"start": float64(3), "end": float64(1)})
+	if err == nil {
+		t.Fatal("expected error for an invalid [start,end) range")
+	}
+}
+
+func TestSummarizeConversationToolPrefersContextTemplateOverride(t *testing.T) {
+	fake := &fakeSummarizer{result: "summary"}
+	tool := NewSummarizeConversationTool(fake, "default template %s")
+
+	sess := &session.Session{}
+	sess.AddMessage(session.Message{Role: "user", Content: "msg"})
+	ctx := WithSession(context.Background(), sess)
+	ctx = WithSummaryTemplate(ctx, "agent-specific template %s")
+
+	if _, err := tool.Execute(ctx, map[string]interface{}{}); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if fake.gotTemplate != "agent-specific template %s" {
+		t.Errorf("gotTemplate = %q, want the agent's override to take precedence", fake.gotTemplate)
+	}
+}
+
+func TestSummarizeConversationToolWrapsSummarizerError(t *testing.T) {
+	fake := &fakeSummarizer{err: strErr("llm unavailable")}
+	tool := NewSummarizeConversationTool(fake, "")
+
+	sess := &session.Session{}
+	sess.AddMessage(session.Message{Role: "user", Content: "msg"})
+	ctx := WithSession(context.Background(), sess)
+
+	_, err := tool.Execute(ctx, map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), "llm unavailable") {
+		t.Errorf("error = %v, want it to wrap the summarizer's error", err)
+	}
+}
+
+type strErr string
+
+func (e strErr) Error() string { return string(e) }
@@ -0,0 +1,33 @@
+package bus
+
+import "time"
+
+// ApprovalDecision 表示用户对一次待批准工具调用做出的决定
+type ApprovalDecision string
+
+const (
+	// ApprovalApproved 用户批准了本次工具调用
+	ApprovalApproved ApprovalDecision = "approved"
+	// ApprovalDenied 用户拒绝了本次工具调用
+	ApprovalDenied ApprovalDecision = "denied"
+	// ApprovalTimedOut 等待用户响应超时，按拒绝处理
+	ApprovalTimedOut ApprovalDecision = "timed_out"
+)
+
+// PendingApproval 是发布到总线上、等待用户确认的一次工具调用请求
+type PendingApproval struct {
+	ID          string
+	SessionKey  string
+	ToolName    string
+	ToolParams  map[string]interface{}
+	RequestedAt time.Time
+}
+
+// ApprovalResponse 是通道收到用户决定后，回传给 Agent 循环的入站响应
+//
+// ID 必须与触发该响应的 PendingApproval.ID 一致，用于关联等待中的工具调用。
+type ApprovalResponse struct {
+	ID          string
+	Decision    ApprovalDecision
+	AlwaysAllow bool
+}
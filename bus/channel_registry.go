@@ -0,0 +1,83 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ChannelSender 是某个出站通道（微信、Telegram、HTTP webhook、WebSocket、clawhub 等）
+// 的发送器，由各通道实现自行注册到 ChannelRegistry。
+type ChannelSender interface {
+	// Send 把一条出站消息发送到该通道
+	Send(ctx context.Context, msg *OutboundMessage) error
+	// Name 返回通道名称，须与 OutboundMessage.Channel 的取值一致
+	Name() string
+	// SupportsStreaming 表示该通道是否支持按 StreamID 增量发送
+	SupportsStreaming() bool
+}
+
+// ApprovalRenderer 是可选接口，由支持交互式 UI 的通道实现，用于把待审批的工具调用
+// 渲染成可点击的按钮而不是纯文本。未实现该接口的通道会退化为普通 Send。
+type ApprovalRenderer interface {
+	SendApproval(ctx context.Context, msg *OutboundMessage) error
+}
+
+// ChannelRegistry 管理已注册的通道发送器，供 Loop 按 OutboundMessage.Channel 路由
+type ChannelRegistry struct {
+	mu      sync.RWMutex
+	senders map[string]ChannelSender
+}
+
+// NewChannelRegistry 创建一个空的通道注册表
+func NewChannelRegistry() *ChannelRegistry {
+	return &ChannelRegistry{senders: make(map[string]ChannelSender)}
+}
+
+// Register 注册一个通道发送器，通常在各通道实现启动时调用
+func (r *ChannelRegistry) Register(sender ChannelSender) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.senders[sender.Name()] = sender
+}
+
+// Get 按名称查找已注册的通道发送器
+func (r *ChannelRegistry) Get(name string) (ChannelSender, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sender, ok := r.senders[name]
+	return sender, ok
+}
+
+// SendError 包装通道发送失败的错误并携带可选的 HTTP 状态码，用于判断是否应当重试
+type SendError struct {
+	StatusCode int
+	Err        error
+}
+
+func (e *SendError) Error() string {
+	if e.StatusCode > 0 {
+		return fmt.Sprintf("channel send failed (status %d): %v", e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("channel send failed: %v", e.Err)
+}
+
+func (e *SendError) Unwrap() error {
+	return e.Err
+}
+
+// IsRetryable 判断一次发送失败是否值得退避重试：未携带状态码的错误（如网络超时）
+// 以及 5xx 服务端错误视为可重试，4xx 等客户端错误视为永久失败
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	sendErr, ok := err.(*SendError)
+	if !ok {
+		return true
+	}
+	return sendErr.StatusCode == 0 || sendErr.StatusCode >= 500
+}
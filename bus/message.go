@@ -0,0 +1,126 @@
+// Package bus 实现入站/出站消息在各通道与 Agent 循环之间的传递。
+package bus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Media 表示入站消息中携带的多媒体附件
+type Media struct {
+	Type     string
+	URL      string
+	Base64   string
+	MimeType string
+}
+
+// InboundMessage 从某个通道收到的一条待处理消息
+type InboundMessage struct {
+	Channel   string
+	ChatID    string
+	Agent     string
+	Content   string
+	Media     []Media
+	Timestamp time.Time
+	Metadata  map[string]interface{}
+
+	// Approval 非空时表示这是通道收到的用户审批决定，而不是一条普通对话消息，
+	// 应当直接投递给等待中的工具调用，不进入正常的会话处理流程。
+	Approval *ApprovalResponse
+}
+
+// IsSystemMessage 判断该消息是否为内部系统消息（如子代理完成回调）
+func (m *InboundMessage) IsSystemMessage() bool {
+	return m.Metadata != nil && m.Metadata["task_id"] != nil
+}
+
+// IsApprovalResponse 判断该消息是否携带用户对一次待审批工具调用做出的决定
+func (m *InboundMessage) IsApprovalResponse() bool {
+	return m.Approval != nil
+}
+
+// SessionKey 返回该消息所属会话的唯一键
+func (m *InboundMessage) SessionKey() string {
+	return m.Channel + ":" + m.ChatID
+}
+
+// IsSummarizeCommand 判断该消息是否为用户主动触发会话压缩的 /summarize 命令
+func (m *InboundMessage) IsSummarizeCommand() bool {
+	return strings.TrimSpace(m.Content) == "/summarize"
+}
+
+// OutboundMessage 待发送给某个通道的一条消息
+type OutboundMessage struct {
+	Channel   string
+	ChatID    string
+	Content   string
+	Timestamp time.Time
+
+	// Approval 非空时表示这是一条待用户审批的请求，通道实现应渲染为可交互的确认 UI
+	Approval *PendingApproval
+
+	// StreamID 非空时表示这是同一次回复中的一个增量片段，取值在整个流中保持一致
+	StreamID string
+	// StreamDone 为 true 表示 StreamID 对应的流已发送完毕，Content 此时是最后一个增量片段（可为空），
+	// 不是完整文本
+	StreamDone bool
+}
+
+// MessageBus 是入站/出站消息队列的抽象
+type MessageBus struct {
+	inbound  chan *InboundMessage
+	outbound chan *OutboundMessage
+}
+
+// NewMessageBus 创建一个带缓冲的消息总线
+func NewMessageBus(bufferSize int) *MessageBus {
+	return &MessageBus{
+		inbound:  make(chan *InboundMessage, bufferSize),
+		outbound: make(chan *OutboundMessage, bufferSize),
+	}
+}
+
+// PublishInbound 发布一条入站消息
+func (b *MessageBus) PublishInbound(ctx context.Context, msg *InboundMessage) error {
+	select {
+	case b.inbound <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ConsumeInbound 消费一条入站消息
+func (b *MessageBus) ConsumeInbound(ctx context.Context) (*InboundMessage, error) {
+	select {
+	case msg := <-b.inbound:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// PublishOutbound 发布一条出站消息
+func (b *MessageBus) PublishOutbound(ctx context.Context, msg *OutboundMessage) error {
+	select {
+	case b.outbound <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ConsumeOutbound 消费一条出站消息
+func (b *MessageBus) ConsumeOutbound(ctx context.Context) (*OutboundMessage, error) {
+	select {
+	case msg := <-b.outbound:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ErrBusClosed 在总线已关闭后继续发布/消费时返回
+var ErrBusClosed = fmt.Errorf("message bus is closed")
@@ -0,0 +1,30 @@
+package bus
+
+import "testing"
+
+func TestInboundMessageIsApprovalResponse(t *testing.T) {
+	plain := &InboundMessage{Content: "hello"}
+	if plain.IsApprovalResponse() {
+		t.Error("plain message should not be an approval response")
+	}
+
+	withApproval := &InboundMessage{Approval: &ApprovalResponse{ID: "abc", Decision: ApprovalApproved}}
+	if !withApproval.IsApprovalResponse() {
+		t.Error("message carrying Approval should be an approval response")
+	}
+}
+
+func TestInboundMessageIsSummarizeCommand(t *testing.T) {
+	cases := map[string]bool{
+		"/summarize":   true,
+		" /summarize ": true,
+		"/summarize x": false,
+		"hello":        false,
+	}
+	for content, want := range cases {
+		msg := &InboundMessage{Content: content}
+		if got := msg.IsSummarizeCommand(); got != want {
+			t.Errorf("IsSummarizeCommand(%q) = %v, want %v", content, got, want)
+		}
+	}
+}
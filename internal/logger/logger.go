@@ -0,0 +1,34 @@
+// Package logger 提供全局日志封装，基于 zap。
+package logger
+
+import "go.uber.org/zap"
+
+var log *zap.Logger
+
+func init() {
+	l, err := zap.NewProduction()
+	if err != nil {
+		l = zap.NewNop()
+	}
+	log = l
+}
+
+// Info 记录 info 级别日志
+func Info(msg string, fields ...zap.Field) {
+	log.Info(msg, fields...)
+}
+
+// Warn 记录 warn 级别日志
+func Warn(msg string, fields ...zap.Field) {
+	log.Warn(msg, fields...)
+}
+
+// Error 记录 error 级别日志
+func Error(msg string, fields ...zap.Field) {
+	log.Error(msg, fields...)
+}
+
+// Debug 记录 debug 级别日志
+func Debug(msg string, fields ...zap.Field) {
+	log.Debug(msg, fields...)
+}
@@ -0,0 +1,62 @@
+// Package providers 定义与具体 LLM 供应商交互的抽象接口。
+package providers
+
+import "context"
+
+// ToolCall 表示 LLM 在一次回复中发起的工具调用
+type ToolCall struct {
+	ID     string
+	Name   string
+	Params map[string]interface{}
+}
+
+// ToolDefinition 描述一个可供 LLM 调用的工具
+type ToolDefinition struct {
+	Name        string
+	Description string
+	Parameters  interface{}
+}
+
+// Message 发往/来自 LLM 的一条对话消息
+type Message struct {
+	Role       string
+	Content    string
+	Images     []string
+	ToolCallID string
+	ToolCalls  []ToolCall
+}
+
+// ChatResponse 表示一次 Chat 调用的结果
+type ChatResponse struct {
+	Content   string
+	ToolCalls []ToolCall
+}
+
+// Provider 是所有 LLM 供应商必须实现的接口
+type Provider interface {
+	Chat(ctx context.Context, messages []Message, tools []ToolDefinition) (*ChatResponse, error)
+
+	// ChatStream 与 Chat 等价，但以增量 Delta 的形式返回结果，用于长生成场景下的流式展示。
+	// 返回的 channel 在流结束（含出错）时必须被关闭；最后一个 Delta 的 FinishReason 非空。
+	ChatStream(ctx context.Context, messages []Message, tools []ToolDefinition) (<-chan Delta, error)
+}
+
+// ToolCallDelta 是一次工具调用参数的增量片段
+//
+// LLM 供应商通常按 Index 分片下发同一个工具调用的 JSON 参数（OpenAI/Anthropic 均如此），
+// ID 和 Name 只在该工具调用的第一个分片中出现，后续分片仅携带 ArgsFragment。
+type ToolCallDelta struct {
+	Index        int
+	ID           string
+	Name         string
+	ArgsFragment string
+}
+
+// Delta 是流式 Chat 响应中的一个增量片段
+type Delta struct {
+	ContentDelta   string
+	ToolCallDeltas []ToolCallDelta
+	// FinishReason 非空表示流已结束，例如 "stop"、"tool_calls"
+	FinishReason string
+	Err          error
+}
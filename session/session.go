@@ -0,0 +1,104 @@
+// Package session 管理对话会话的生命周期与持久化。
+package session
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Media 表示消息中携带的多媒体附件
+type Media struct {
+	Type     string
+	URL      string
+	Base64   string
+	MimeType string
+}
+
+// ToolCall 表示一次工具调用
+type ToolCall struct {
+	ID     string
+	Name   string
+	Params map[string]interface{}
+}
+
+// Message 会话中的一条消息
+type Message struct {
+	Role       string
+	Content    string
+	Media      []Media
+	Images     []string
+	Timestamp  time.Time
+	ToolCalls  []ToolCall
+	ToolCallID string
+	Metadata   map[string]interface{}
+}
+
+// Session 单个会话的消息历史与元数据
+type Session struct {
+	Key      string
+	Channel  string
+	ChatID   string
+	Messages []Message
+	Metadata map[string]interface{}
+
+	mu sync.Mutex
+}
+
+// AddMessage 向会话追加一条消息
+func (s *Session) AddMessage(msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Messages = append(s.Messages, msg)
+}
+
+// GetHistory 返回最近 n 条消息
+func (s *Session) GetHistory(n int) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n <= 0 || n >= len(s.Messages) {
+		return append([]Message(nil), s.Messages...)
+	}
+	return append([]Message(nil), s.Messages[len(s.Messages)-n:]...)
+}
+
+// Manager 负责会话的获取、创建与持久化
+type Manager struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewManager 创建会话管理器
+func NewManager() *Manager {
+	return &Manager{sessions: make(map[string]*Session)}
+}
+
+// GetOrCreate 获取已存在的会话，不存在则创建
+func (m *Manager) GetOrCreate(key string) (*Session, error) {
+	if key == "" {
+		return nil, fmt.Errorf("session key must not be empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sess, ok := m.sessions[key]; ok {
+		return sess, nil
+	}
+
+	sess := &Session{Key: key, Metadata: make(map[string]interface{})}
+	m.sessions[key] = sess
+	return sess, nil
+}
+
+// Save 持久化会话（当前实现仅保留内存态）
+func (m *Manager) Save(sess *Session) error {
+	if sess == nil {
+		return fmt.Errorf("cannot save nil session")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[sess.Key] = sess
+	return nil
+}